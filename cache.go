@@ -0,0 +1,346 @@
+package isdayoff
+
+import (
+	"container/list"
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+)
+
+// YearKey identifies a year calendar of a country built with particular flags
+type YearKey struct {
+	Year        int
+	CountryCode CountryCode
+	Pre         bool
+	Covid       bool
+	SixDayWeek  bool
+}
+
+// yearKey returns key of the year calendar params refer to, applying API defaults
+func (p Params) yearKey(year int) YearKey {
+	key := YearKey{Year: year, CountryCode: CountryCodeRussia}
+	if p.CountryCode != nil {
+		key.CountryCode = *p.CountryCode
+	}
+	if p.Pre != nil {
+		key.Pre = *p.Pre
+	}
+	if p.Covid != nil {
+		key.Covid = *p.Covid
+	}
+	if p.SixDayWeek != nil {
+		key.SixDayWeek = *p.SixDayWeek
+	}
+	return key
+}
+
+// params returns request params fetching the whole year of key
+func (k YearKey) params() Params {
+	cc := k.CountryCode
+	return Params{
+		Year:        k.Year,
+		CountryCode: &cc,
+		Pre:         &k.Pre,
+		Covid:       &k.Covid,
+		SixDayWeek:  &k.SixDayWeek,
+	}
+}
+
+// Cache stores year calendars. Implementations must be safe for concurrent use
+// and must not share returned slices with the stored data.
+// Package cachetest contains conformance tests for implementations.
+type Cache interface {
+	// Get returns the year calendar, reporting false if it is missing or expired
+	Get(ctx context.Context, key YearKey) ([]DayType, bool, error)
+	// Set stores the year calendar
+	Set(ctx context.Context, key YearKey, days []DayType) error
+	// Delete removes the year calendar; deleting a missing key is not an error
+	Delete(ctx context.Context, key YearKey) error
+}
+
+// StaleCache is a Cache able to return expired entries.
+// Client serves them when the API is unreachable.
+type StaleCache interface {
+	Cache
+	// GetStale returns the year calendar regardless of its age along with the time it was stored
+	GetStale(ctx context.Context, key YearKey) ([]DayType, time.Time, bool, error)
+}
+
+// CacheStats contains cache usage counters
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+	Entries   int
+}
+
+// MemoryCache keeps year calendars in memory.
+// Entries expire after TTL and least recently used ones are evicted when the cache is full.
+type MemoryCache struct {
+	mu         sync.Mutex
+	clock      Clock
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List
+	items      map[YearKey]*list.Element
+	stats      CacheStats
+}
+
+type memoryEntry struct {
+	key       YearKey
+	days      []DayType
+	expiresAt time.Time
+}
+
+// CacheOption configures MemoryCache and FileCache
+type CacheOption func(*cacheOptions)
+
+type cacheOptions struct {
+	clock Clock
+}
+
+// WithCacheClock sets clock used for cache expiration
+func WithCacheClock(clock Clock) CacheOption {
+	return func(o *cacheOptions) {
+		if clock != nil {
+			o.clock = clock
+		}
+	}
+}
+
+func newCacheOptions(opts []CacheOption) cacheOptions {
+	o := cacheOptions{clock: systemClock{}}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// NewMemoryCache creates cache. Zero ttl means entries never expire,
+// zero maxEntries means the number of entries is not limited.
+func NewMemoryCache(ttl time.Duration, maxEntries int, opts ...CacheOption) *MemoryCache {
+	return &MemoryCache{
+		clock:      newCacheOptions(opts).clock,
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		items:      map[YearKey]*list.Element{},
+	}
+}
+
+// Get returns a copy of the cached year calendar
+func (m *MemoryCache) Get(_ context.Context, key YearKey) ([]DayType, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	el, ok := m.items[key]
+	if ok {
+		entry := el.Value.(*memoryEntry)
+		if entry.expiresAt.IsZero() || m.clock.Now().Before(entry.expiresAt) {
+			m.order.MoveToFront(el)
+			m.stats.Hits++
+			return slices.Clone(entry.days), true, nil
+		}
+		m.remove(el)
+	}
+	m.stats.Misses++
+	return nil, false, nil
+}
+
+// Set stores a copy of the year calendar
+func (m *MemoryCache) Set(_ context.Context, key YearKey, days []DayType) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entry := &memoryEntry{key: key, days: slices.Clone(days)}
+	if m.ttl > 0 {
+		entry.expiresAt = m.clock.Now().Add(m.ttl)
+	}
+	if el, ok := m.items[key]; ok {
+		el.Value = entry
+		m.order.MoveToFront(el)
+		return nil
+	}
+	m.items[key] = m.order.PushFront(entry)
+	for m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		m.remove(m.order.Back())
+		m.stats.Evictions++
+	}
+	return nil
+}
+
+// Delete removes the year calendar from the cache
+func (m *MemoryCache) Delete(_ context.Context, key YearKey) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if el, ok := m.items[key]; ok {
+		m.remove(el)
+	}
+	return nil
+}
+
+// Stats returns cache usage counters
+func (m *MemoryCache) Stats() CacheStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	stats := m.stats
+	stats.Entries = m.order.Len()
+	return stats
+}
+
+func (m *MemoryCache) remove(el *list.Element) {
+	m.order.Remove(el)
+	delete(m.items, el.Value.(*memoryEntry).key)
+}
+
+// WithCache adds a cache layer. Client fetches whole year calendars once and answers
+// GetBy, GetByPeriod, Today and Tomorrow by slicing the cached data.
+// Layers are consulted in the order they were added; a hit fills the layers before it.
+// Cache errors are treated as misses so that a failing store never breaks requests.
+func WithCache(cache Cache) Option {
+	return func(c *Client) {
+		if cache != nil {
+			c.caches = append(c.caches, cache)
+		}
+	}
+}
+
+// cached reports whether client answers requests from year calendars
+func (c *Client) cached() bool {
+	return len(c.caches) > 0
+}
+
+// year returns calendar of the whole year from the caches, fetching it on a miss.
+// When the API is unreachable, outdated data from a StaleCache is returned
+// together with StaleError.
+func (c *Client) year(ctx context.Context, key YearKey) ([]DayType, error) {
+	for i, cache := range c.caches {
+		if days, ok, err := cache.Get(ctx, key); err == nil && ok && len(days) == daysIn(key.Year) {
+			for _, upper := range c.caches[:i] {
+				upper.Set(ctx, key, days)
+			}
+			return days, nil
+		}
+	}
+	if c.offline != nil {
+		return nil, c.missing(key)
+	}
+
+	days, err := c.getBy(ctx, key.params())
+	if err == nil && len(days) != daysIn(key.Year) {
+		return nil, fmt.Errorf("unexpected number of days for year %d: %d", key.Year, len(days))
+	}
+	if err != nil {
+		if unreachable(ctx, err) {
+			for _, cache := range c.caches {
+				stale, ok := cache.(StaleCache)
+				if !ok {
+					continue
+				}
+				if days, fetchedAt, ok, _ := stale.GetStale(ctx, key); ok && len(days) == daysIn(key.Year) {
+					return days, &StaleError{FetchedAt: fetchedAt, Err: err}
+				}
+			}
+		}
+		return nil, err
+	}
+
+	for _, cache := range c.caches {
+		cache.Set(ctx, key, days)
+	}
+	return days, nil
+}
+
+// daysIn returns number of days in the year
+func daysIn(year int) int {
+	return time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay()
+}
+
+// yearBounds returns bounds of the month or the day params refer to within the year.
+// It reports false if params do not point into the year.
+func yearBounds(params Params) (start, end int, ok bool) {
+	if params.Month == nil {
+		if params.Day != nil {
+			return 0, 0, false
+		}
+		return 0, daysIn(params.Year), true
+	}
+	month := *params.Month
+	if month < time.January || month > time.December {
+		return 0, 0, false
+	}
+	first := time.Date(params.Year, month, 1, 0, 0, 0, 0, time.UTC)
+	start = first.YearDay() - 1
+	length := first.AddDate(0, 1, -1).Day()
+	if params.Day == nil {
+		return start, start + length, true
+	}
+	if *params.Day < 1 || *params.Day > length {
+		return 0, 0, false
+	}
+	return start + *params.Day - 1, start + *params.Day, true
+}
+
+// cachedGetBy answers GetBy from the year cache
+func (c *Client) cachedGetBy(ctx context.Context, params Params) ([]DayType, bool, error) {
+	if params.Year <= 0 {
+		return nil, false, nil
+	}
+	start, end, ok := yearBounds(params)
+	if !ok {
+		return nil, false, nil
+	}
+	days, err := c.year(ctx, params.yearKey(params.Year))
+	if days == nil {
+		return nil, true, err
+	}
+	return days[start:end], true, err
+}
+
+// cachedPeriod answers GetByPeriod from the year cache
+func (c *Client) cachedPeriod(ctx context.Context, from, to Date, params Params) ([]DayType, error) {
+	var stale error
+	result := make([]DayType, 0, daysBetween(from.Time(), to.Time())+1)
+	for year := from.Year; year <= to.Year; year++ {
+		days, err := c.year(ctx, params.yearKey(year))
+		if days == nil {
+			return nil, err
+		}
+		if err != nil {
+			stale = err
+		}
+		start, end := 0, len(days)
+		if year == from.Year {
+			start = from.Time().YearDay() - 1
+		}
+		if year == to.Year {
+			end = to.Time().YearDay()
+		}
+		result = append(result, days[start:end]...)
+	}
+	return result, stale
+}
+
+// cachedAlias answers Today and Tomorrow from the year cache
+func (c *Client) cachedAlias(ctx context.Context, offset int, params Params) (*DayType, bool, error) {
+	tz := defaultTZ
+	if params.TZ != nil {
+		tz = *params.TZ
+	}
+	loc, err := time.LoadLocation(tz)
+	if err != nil {
+		return nil, false, nil
+	}
+	date := c.clock.Now().In(loc).AddDate(0, 0, offset)
+
+	days, err := c.year(ctx, params.yearKey(date.Year()))
+	if days == nil {
+		return nil, true, err
+	}
+	result := days[date.YearDay()-1]
+	return &result, true, err
+}
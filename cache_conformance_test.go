@@ -0,0 +1,25 @@
+package isdayoff_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+	"github.com/kotopheiop/isdayoff/cachetest"
+)
+
+func TestMemoryCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(t *testing.T) isdayoff.Cache {
+		return isdayoff.NewMemoryCache(time.Hour, 0)
+	})
+}
+
+func TestFileCacheConformance(t *testing.T) {
+	cachetest.Run(t, func(t *testing.T) isdayoff.Cache {
+		cache, err := isdayoff.NewFileCache(t.TempDir(), time.Hour)
+		if err != nil {
+			t.Fatalf("NewFileCache() failed: %v", err)
+		}
+		return cache
+	})
+}
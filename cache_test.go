@@ -0,0 +1,192 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestMemoryCacheServesSlices(t *testing.T) {
+	cache := NewMemoryCache(time.Hour, 10)
+	clock := newFakeClock(time.Date(2024, time.March, 9, 12, 0, 0, 0, time.UTC))
+	api, client := newFakeAPI(t, WithCache(cache), WithClock(clock))
+	_, direct := newFakeAPI(t)
+
+	cc := CountryCodeKazakhstan
+	month := time.February
+	day := 29
+	utc := "UTC"
+	params := Params{CountryCode: &cc}
+
+	checks := []struct {
+		name string
+		call func(c *Client) ([]DayType, error)
+	}{
+		{"year", func(c *Client) ([]DayType, error) {
+			return c.GetBy(Params{Year: 2024, CountryCode: &cc})
+		}},
+		{"month", func(c *Client) ([]DayType, error) {
+			return c.GetBy(Params{Year: 2024, Month: &month, CountryCode: &cc})
+		}},
+		{"day", func(c *Client) ([]DayType, error) {
+			return c.GetBy(Params{Year: 2024, Month: &month, Day: &day, CountryCode: &cc})
+		}},
+		{"period across years", func(c *Client) ([]DayType, error) {
+			return c.GetByPeriod("20231225", "20240110", params)
+		}},
+	}
+	for _, tt := range checks {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call(client)
+			if err != nil {
+				t.Fatalf("cached call failed: %v", err)
+			}
+			expected, err := tt.call(direct)
+			if err != nil {
+				t.Fatalf("direct call failed: %v", err)
+			}
+			if !slices.Equal(got, expected) {
+				t.Errorf("cached result %v differs from API result %v", got, expected)
+			}
+		})
+	}
+
+	today, err := client.Today(Params{CountryCode: &cc, TZ: &utc})
+	if err != nil || *today != DayTypeNonWorking {
+		t.Errorf("Today() = %v, %v; expected non-working Saturday", today, err)
+	}
+	tomorrow, err := client.Tomorrow(Params{CountryCode: &cc, TZ: &utc})
+	if err != nil || *tomorrow != DayTypeNonWorking {
+		t.Errorf("Tomorrow() = %v, %v; expected non-working Sunday", tomorrow, err)
+	}
+
+	// 2023 и 2024 годы запрошены по одному разу
+	if got := api.requestCount(); got != 2 {
+		t.Errorf("server got %d requests, expected 2: %v", got, api.requests)
+	}
+	stats := cache.Stats()
+	if stats.Misses != 2 || stats.Hits != 5 || stats.Entries != 2 {
+		t.Errorf("Stats() = %+v, expected 2 misses, 5 hits and 2 entries", stats)
+	}
+}
+
+func TestMemoryCacheFlagsInKey(t *testing.T) {
+	api, client := newFakeAPI(t, WithCache(NewMemoryCache(0, 0)))
+
+	sd := true
+	by := CountryCodeBelarus
+	for _, params := range []Params{
+		{Year: 2024},
+		{Year: 2024, SixDayWeek: &sd},
+		{Year: 2024, CountryCode: &by},
+		{Year: 2024},
+	} {
+		if _, err := client.GetBy(params); err != nil {
+			t.Fatalf("GetBy() failed: %v", err)
+		}
+	}
+	if got := api.requestCount(); got != 3 {
+		t.Errorf("server got %d requests, expected 3: %v", got, api.requests)
+	}
+}
+
+func TestMemoryCacheRejectsInvalidParams(t *testing.T) {
+	api, client := newFakeAPI(t, WithCache(NewMemoryCache(0, 0)))
+
+	month := time.Month(13)
+	var validationErr *ValidationError
+	if _, err := client.GetBy(Params{Year: 2024, Month: &month}); !errors.As(err, &validationErr) {
+		t.Errorf("GetBy() with month 13 error = %v, expected ValidationError", err)
+	}
+	// некорректные параметры отклоняются до обращения к кэшу и к API
+	if got := api.requestCount(); got != 0 {
+		t.Errorf("server got %d requests, expected 0: %v", got, api.requests)
+	}
+}
+
+func TestMemoryCacheTTLAndEviction(t *testing.T) {
+	ctx := context.Background()
+	clock := newFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	cache := NewMemoryCache(time.Minute, 2, WithCacheClock(clock))
+
+	key := func(year int) YearKey { return YearKey{Year: year, CountryCode: CountryCodeRussia} }
+	days := []DayType{DayTypeWorking, DayTypeNonWorking}
+
+	cache.Set(ctx, key(2022), days)
+	cache.Set(ctx, key(2023), days)
+	if _, ok, _ := cache.Get(ctx, key(2022)); !ok {
+		t.Fatal("Get(2022) missed fresh entry")
+	}
+	cache.Set(ctx, key(2024), days)
+	if _, ok, _ := cache.Get(ctx, key(2023)); ok {
+		t.Error("Get(2023) hit entry that should be evicted as least recently used")
+	}
+
+	got, ok, _ := cache.Get(ctx, key(2024))
+	if !ok || !slices.Equal(got, days) {
+		t.Fatalf("Get(2024) = %v, %v", got, ok)
+	}
+	got[0] = DayTypeHalfHoliday
+	if again, _, _ := cache.Get(ctx, key(2024)); again[0] != DayTypeWorking {
+		t.Error("modifying returned slice changed cached data")
+	}
+
+	clock.Advance(time.Minute)
+	if _, ok, _ := cache.Get(ctx, key(2024)); ok {
+		t.Error("Get(2024) hit expired entry")
+	}
+
+	cache.Set(ctx, key(2025), days)
+	cache.Delete(ctx, key(2025))
+	if _, ok, _ := cache.Get(ctx, key(2025)); ok {
+		t.Error("Get(2025) hit deleted entry")
+	}
+
+	stats := cache.Stats()
+	expected := CacheStats{Hits: 3, Misses: 3, Evictions: 1, Entries: 1}
+	if stats != expected {
+		t.Errorf("Stats() = %+v, expected %+v", stats, expected)
+	}
+}
+
+// failingCache имитирует недоступное внешнее хранилище
+type failingCache struct{}
+
+func (failingCache) Get(context.Context, YearKey) ([]DayType, bool, error) {
+	return nil, false, errors.New("connection refused")
+}
+func (failingCache) Set(context.Context, YearKey, []DayType) error {
+	return errors.New("connection refused")
+}
+func (failingCache) Delete(context.Context, YearKey) error { return errors.New("connection refused") }
+
+func TestCacheLayers(t *testing.T) {
+	ctx := context.Background()
+	memory := NewMemoryCache(0, 0)
+	files, err := NewFileCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewFileCache() failed: %v", err)
+	}
+	api, client := newFakeAPI(t, WithCache(memory), WithCache(failingCache{}), WithCache(files))
+
+	key := YearKey{Year: 2024, CountryCode: CountryCodeRussia}
+	if _, err := client.GetBy(Params{Year: 2024}); err != nil {
+		t.Fatalf("GetBy() with failing cache layer failed: %v", err)
+	}
+	if _, ok, _ := files.Get(ctx, key); !ok {
+		t.Error("fetched calendar was not stored in the file layer")
+	}
+
+	memory.Delete(ctx, key)
+	if _, err := client.GetBy(Params{Year: 2024}); err != nil {
+		t.Fatalf("GetBy() failed: %v", err)
+	}
+	if _, ok, _ := memory.Get(ctx, key); !ok {
+		t.Error("hit in the file layer did not fill the memory layer")
+	}
+	if got := api.requestCount(); got != 1 {
+		t.Errorf("server got %d requests, expected 1", got)
+	}
+}
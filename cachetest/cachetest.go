@@ -0,0 +1,195 @@
+// Package cachetest contains conformance tests for isdayoff.Cache implementations.
+//
+// Third-party caches (Redis, memcached, ...) can verify their behaviour with
+//
+//	func TestRedisCache(t *testing.T) {
+//		cachetest.Run(t, func(t *testing.T) isdayoff.Cache {
+//			return newRedisCache(t)
+//		})
+//	}
+package cachetest
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+// Run runs the conformance suite. newCache must return an empty cache for every call.
+func Run(t *testing.T, newCache func(t *testing.T) isdayoff.Cache) {
+	t.Helper()
+
+	tests := []struct {
+		name string
+		test func(t *testing.T, cache isdayoff.Cache)
+	}{
+		{"MissOnEmpty", testMissOnEmpty},
+		{"SetGet", testSetGet},
+		{"KeysAreIndependent", testKeysAreIndependent},
+		{"Overwrite", testOverwrite},
+		{"Delete", testDelete},
+		{"ReturnedSliceIsCopy", testReturnedSliceIsCopy},
+		{"Concurrent", testConcurrent},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.test(t, newCache(t))
+		})
+	}
+}
+
+// Year returns a deterministic calendar of the year for tests
+func Year(year int, seed int) []isdayoff.DayType {
+	types := []isdayoff.DayType{
+		isdayoff.DayTypeWorking,
+		isdayoff.DayTypeNonWorking,
+		isdayoff.DayTypeHalfHoliday,
+		isdayoff.DayTypeWorkingCovid,
+	}
+	days := make([]isdayoff.DayType, 365)
+	if year%4 == 0 && (year%100 != 0 || year%400 == 0) {
+		days = make([]isdayoff.DayType, 366)
+	}
+	for i := range days {
+		days[i] = types[(i*7+seed)%len(types)]
+	}
+	return days
+}
+
+var key = isdayoff.YearKey{Year: 2024, CountryCode: isdayoff.CountryCodeRussia}
+
+func mustGet(t *testing.T, cache isdayoff.Cache, key isdayoff.YearKey) ([]isdayoff.DayType, bool) {
+	t.Helper()
+	days, ok, err := cache.Get(context.Background(), key)
+	if err != nil {
+		t.Fatalf("Get(%+v) failed: %v", key, err)
+	}
+	return days, ok
+}
+
+func mustSet(t *testing.T, cache isdayoff.Cache, key isdayoff.YearKey, days []isdayoff.DayType) {
+	t.Helper()
+	if err := cache.Set(context.Background(), key, days); err != nil {
+		t.Fatalf("Set(%+v) failed: %v", key, err)
+	}
+}
+
+func testMissOnEmpty(t *testing.T, cache isdayoff.Cache) {
+	if days, ok := mustGet(t, cache, key); ok {
+		t.Errorf("Get() on empty cache returned %d days", len(days))
+	}
+}
+
+func testSetGet(t *testing.T, cache isdayoff.Cache) {
+	days := Year(key.Year, 1)
+	mustSet(t, cache, key, days)
+	got, ok := mustGet(t, cache, key)
+	if !ok {
+		t.Fatal("Get() missed stored calendar")
+	}
+	if !slices.Equal(got, days) {
+		t.Errorf("Get() = %v, expected %v", got, days)
+	}
+}
+
+func testKeysAreIndependent(t *testing.T, cache isdayoff.Cache) {
+	keys := []isdayoff.YearKey{
+		key,
+		{Year: 2023, CountryCode: isdayoff.CountryCodeRussia},
+		{Year: 2024, CountryCode: isdayoff.CountryCodeKazakhstan},
+		{Year: 2024, CountryCode: isdayoff.CountryCodeRussia, Pre: true},
+		{Year: 2024, CountryCode: isdayoff.CountryCodeRussia, Covid: true},
+		{Year: 2024, CountryCode: isdayoff.CountryCodeRussia, SixDayWeek: true},
+	}
+	for i, k := range keys {
+		mustSet(t, cache, k, Year(k.Year, i))
+	}
+	for i, k := range keys {
+		got, ok := mustGet(t, cache, k)
+		if !ok || !slices.Equal(got, Year(k.Year, i)) {
+			t.Errorf("Get(%+v) returned data stored under another key", k)
+		}
+	}
+}
+
+func testOverwrite(t *testing.T, cache isdayoff.Cache) {
+	mustSet(t, cache, key, Year(key.Year, 1))
+	mustSet(t, cache, key, Year(key.Year, 2))
+	got, ok := mustGet(t, cache, key)
+	if !ok || !slices.Equal(got, Year(key.Year, 2)) {
+		t.Error("Get() after overwrite did not return the latest calendar")
+	}
+}
+
+func testDelete(t *testing.T, cache isdayoff.Cache) {
+	ctx := context.Background()
+	other := isdayoff.YearKey{Year: 2025, CountryCode: isdayoff.CountryCodeRussia}
+	mustSet(t, cache, key, Year(key.Year, 1))
+	mustSet(t, cache, other, Year(other.Year, 1))
+
+	if err := cache.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, ok := mustGet(t, cache, key); ok {
+		t.Error("Get() returned deleted calendar")
+	}
+	if _, ok := mustGet(t, cache, other); !ok {
+		t.Error("Delete() removed calendar stored under another key")
+	}
+	if err := cache.Delete(ctx, key); err != nil {
+		t.Errorf("Delete() of missing key failed: %v", err)
+	}
+}
+
+func testReturnedSliceIsCopy(t *testing.T, cache isdayoff.Cache) {
+	days := Year(key.Year, 1)
+	mustSet(t, cache, key, days)
+	days[0] = isdayoff.DayTypeWorkingCovid
+
+	got, _ := mustGet(t, cache, key)
+	if got[0] != Year(key.Year, 1)[0] {
+		t.Fatal("modifying slice passed to Set() changed cached data")
+	}
+	got[1] = isdayoff.DayTypeWorkingCovid
+	again, _ := mustGet(t, cache, key)
+	if again[1] != Year(key.Year, 1)[1] {
+		t.Error("modifying slice returned by Get() changed cached data")
+	}
+}
+
+func testConcurrent(t *testing.T, cache isdayoff.Cache) {
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	errs := make(chan error, 64)
+	for i := range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			k := isdayoff.YearKey{Year: 2000 + i%4, CountryCode: isdayoff.CountryCodeRussia}
+			for range 10 {
+				if err := cache.Set(ctx, k, Year(k.Year, 0)); err != nil {
+					errs <- err
+					return
+				}
+				days, ok, err := cache.Get(ctx, k)
+				if err != nil {
+					errs <- err
+					return
+				}
+				if ok && !slices.Equal(days, Year(k.Year, 0)) {
+					errs <- fmt.Errorf("Get(%+v) returned inconsistent data", k)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,115 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"iter"
+	"time"
+)
+
+// ErrOutOfRange is returned when a date is outside of the calendar
+var ErrOutOfRange = errors.New("date is out of calendar range")
+
+// Calendar is a production calendar of consecutive days starting at Start.
+// Dates are compared by their calendar date in their own location,
+// so time of day and time zone do not affect lookups.
+type Calendar struct {
+	Start       time.Time // первый день календаря, полночь UTC
+	CountryCode CountryCode
+	Pre         bool
+	Covid       bool
+	SixDayWeek  bool
+	Days        []DayType
+}
+
+// Calendar returns calendar of the whole year
+func (c *Client) Calendar(year int, params Params) (*Calendar, error) {
+	return c.CalendarContext(context.Background(), year, params)
+}
+
+// CalendarContext returns calendar of the whole year using the provided context
+func (c *Client) CalendarContext(ctx context.Context, year int, params Params) (*Calendar, error) {
+	return FetchCalendar(ctx, c, year, params)
+}
+
+// FetchCalendar requests the whole year from the provider. Month and Day of params
+// are ignored. Stale data is returned together with StaleError.
+func FetchCalendar(ctx context.Context, p Provider, year int, params Params) (*Calendar, error) {
+	params = resolveParams(p, params)
+	params.Year = year
+	params.Month = nil
+	params.Day = nil
+
+	days, err := p.GetByContext(ctx, params)
+	if days == nil {
+		return nil, err
+	}
+	if len(days) != daysIn(year) {
+		return nil, fmt.Errorf("unexpected number of days for year %d: %d", year, len(days))
+	}
+
+	key := params.yearKey(year)
+	return &Calendar{
+		Start:       time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CountryCode: key.CountryCode,
+		Pre:         key.Pre,
+		Covid:       key.Covid,
+		SixDayWeek:  key.SixDayWeek,
+		Days:        days,
+	}, err
+}
+
+// dateOf returns midnight UTC of the calendar date of t
+func dateOf(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// daysBetween returns number of days from one calendar date to another
+func daysBetween(from, to time.Time) int {
+	return int(dateOf(to).Sub(dateOf(from)).Hours() / 24)
+}
+
+// End returns the last day of the calendar
+func (cal *Calendar) End() time.Time {
+	return cal.Start.AddDate(0, 0, len(cal.Days)-1)
+}
+
+// Contains reports whether the date is within the calendar
+func (cal *Calendar) Contains(t time.Time) bool {
+	i := daysBetween(cal.Start, t)
+	return i >= 0 && i < len(cal.Days)
+}
+
+// At returns type of the day
+func (cal *Calendar) At(t time.Time) (DayType, error) {
+	if !cal.Contains(t) {
+		return "", fmt.Errorf("%s: %w", t.Format(time.DateOnly), ErrOutOfRange)
+	}
+	return cal.Days[daysBetween(cal.Start, t)], nil
+}
+
+// Range returns types of days from one date to another inclusive.
+// The result shares memory with Days.
+func (cal *Calendar) Range(from, to time.Time) ([]DayType, error) {
+	if err := validateOrder(from, to); err != nil {
+		return nil, err
+	}
+	for _, t := range []time.Time{from, to} {
+		if !cal.Contains(t) {
+			return nil, fmt.Errorf("%s: %w", t.Format(time.DateOnly), ErrOutOfRange)
+		}
+	}
+	return cal.Days[daysBetween(cal.Start, from) : daysBetween(cal.Start, to)+1], nil
+}
+
+// All iterates over dates of the calendar and their types
+func (cal *Calendar) All() iter.Seq2[time.Time, DayType] {
+	return func(yield func(time.Time, DayType) bool) {
+		for i, day := range cal.Days {
+			if !yield(cal.Start.AddDate(0, 0, i), day) {
+				return
+			}
+		}
+	}
+}
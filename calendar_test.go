@@ -0,0 +1,108 @@
+package isdayoff
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCalendar(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.holidays["20240229"] = true
+
+	cc := CountryCodeBelarus
+	pre := true
+	cal, err := client.Calendar(2024, Params{CountryCode: &cc, Pre: &pre})
+	if err != nil {
+		t.Fatalf("Calendar(2024) failed: %v", err)
+	}
+	if !cal.Start.Equal(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)) || !cal.End().Equal(time.Date(2024, time.December, 31, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("Calendar(2024) spans %v - %v", cal.Start, cal.End())
+	}
+	if cal.CountryCode != CountryCodeBelarus || !cal.Pre || cal.Covid || cal.SixDayWeek {
+		t.Errorf("Calendar(2024) flags = %+v", cal)
+	}
+	if len(cal.Days) != 366 {
+		t.Fatalf("Calendar(2024) has %d days, expected 366", len(cal.Days))
+	}
+
+	msk := time.FixedZone("MSK", 3*60*60)
+	tests := []struct {
+		name     string
+		date     time.Time
+		expected DayType
+	}{
+		{"first day", time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), DayTypeWorking},
+		{"leap day", time.Date(2024, time.February, 29, 15, 30, 0, 0, time.UTC), DayTypeNonWorking},
+		{"day after leap day", time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC), DayTypeWorking},
+		{"saturday", time.Date(2024, time.March, 2, 0, 0, 0, 0, time.UTC), DayTypeNonWorking},
+		{"last day", time.Date(2024, time.December, 31, 23, 59, 0, 0, time.UTC), DayTypeWorking},
+		// 00:30 по Москве 4 марта — это ещё 3 марта по UTC, но дата берётся в собственной зоне
+		{"date in own location", time.Date(2024, time.March, 4, 0, 30, 0, 0, msk), DayTypeWorking},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := cal.At(tt.date)
+			if err != nil {
+				t.Fatalf("At(%v) failed: %v", tt.date, err)
+			}
+			if got != tt.expected {
+				t.Errorf("At(%v) = %v, expected %v", tt.date, got, tt.expected)
+			}
+		})
+	}
+
+	for _, date := range []time.Time{
+		time.Date(2023, time.December, 31, 0, 0, 0, 0, time.UTC),
+		time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+	} {
+		if _, err := cal.At(date); !errors.Is(err, ErrOutOfRange) {
+			t.Errorf("At(%v) error = %v, expected ErrOutOfRange", date, err)
+		}
+	}
+}
+
+func TestCalendarRangeAndAll(t *testing.T) {
+	_, client := newFakeAPI(t)
+	cal, err := client.Calendar(2023, Params{})
+	if err != nil {
+		t.Fatalf("Calendar(2023) failed: %v", err)
+	}
+
+	week, err := cal.Range(time.Date(2023, time.March, 6, 0, 0, 0, 0, time.UTC), time.Date(2023, time.March, 12, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Range() failed: %v", err)
+	}
+	expectedWeek := []DayType{"0", "0", "0", "0", "0", "1", "1"}
+	if !slices.Equal(week, expectedWeek) {
+		t.Errorf("Range() = %v, expected %v", week, expectedWeek)
+	}
+	if _, err := cal.Range(time.Date(2023, time.March, 12, 0, 0, 0, 0, time.UTC), time.Date(2023, time.March, 6, 0, 0, 0, 0, time.UTC)); err == nil {
+		t.Error("Range() with reversed dates should fail")
+	}
+	if _, err := cal.Range(time.Date(2023, time.December, 30, 0, 0, 0, 0, time.UTC), time.Date(2024, time.January, 2, 0, 0, 0, 0, time.UTC)); !errors.Is(err, ErrOutOfRange) {
+		t.Errorf("Range() beyond the calendar error = %v, expected ErrOutOfRange", err)
+	}
+
+	count := 0
+	expected := cal.Start
+	for date, day := range cal.All() {
+		if !date.Equal(expected) {
+			t.Fatalf("All() yielded %v, expected %v", date, expected)
+		}
+		if day != cal.Days[count] {
+			t.Fatalf("All() yielded %v for %v, expected %v", day, date, cal.Days[count])
+		}
+		expected = expected.AddDate(0, 0, 1)
+		count++
+	}
+	if count != 365 {
+		t.Errorf("All() yielded %d days, expected 365", count)
+	}
+	for date := range cal.All() {
+		if date.Month() == time.February {
+			break
+		}
+	}
+}
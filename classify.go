@@ -0,0 +1,137 @@
+package isdayoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// DayKind explains why a day is working or off, combining the API code with the weekday
+type DayKind int
+
+const (
+	// DayKindRegularWorkday working day of the ordinary week
+	DayKindRegularWorkday DayKind = iota
+	// DayKindWeekend day off of the ordinary week
+	DayKindWeekend
+	// DayKindHoliday public holiday from the catalogue that is a day off
+	DayKindHoliday
+	// DayKindTransferredWorkday working day that falls on an ordinary weekend
+	DayKindTransferredWorkday
+	// DayKindShortenedPreHoliday shortened working day before a holiday
+	DayKindShortenedPreHoliday
+	// DayKindTransferredOff day off on an ordinary working day that is not
+	// a holiday of the catalogue, usually moved by a transfer
+	DayKindTransferredOff
+)
+
+var dayKindNames = map[DayKind]string{
+	DayKindRegularWorkday:      "regular workday",
+	DayKindWeekend:             "weekend",
+	DayKindHoliday:             "holiday",
+	DayKindTransferredWorkday:  "transferred workday",
+	DayKindShortenedPreHoliday: "shortened pre-holiday",
+	DayKindTransferredOff:      "transferred day off",
+}
+
+func (k DayKind) String() string {
+	if name, ok := dayKindNames[k]; ok {
+		return name
+	}
+	return fmt.Sprintf("DayKind(%d)", int(k))
+}
+
+// DayInfo describes a classified day
+type DayInfo struct {
+	Date    time.Time // полночь UTC
+	Type    DayType
+	Kind    DayKind
+	Weekend bool     // выходной по обычному графику недели
+	Holiday *Holiday // праздник из каталога, nil если его нет
+}
+
+// isWeekend reports whether the weekday is off in the ordinary week:
+// Saturday and Sunday, or only Sunday for the six-day week
+func isWeekend(weekday time.Weekday, sixDayWeek bool) bool {
+	return weekday == time.Sunday || (weekday == time.Saturday && !sixDayWeek)
+}
+
+// classify combines the day type with the weekday of the date and the holiday catalogue
+func classify(t time.Time, day DayType, cc CountryCode, sixDayWeek bool) DayInfo {
+	date := dateOf(t)
+	info := DayInfo{Date: date, Type: day, Weekend: isWeekend(date.Weekday(), sixDayWeek)}
+	if h, ok := HolidayOn(cc, date); ok {
+		info.Holiday = &h
+	}
+	switch {
+	case day.IsShortened():
+		info.Kind = DayKindShortenedPreHoliday
+	case day.IsWorking() && info.Weekend:
+		info.Kind = DayKindTransferredWorkday
+	case day.IsWorking():
+		info.Kind = DayKindRegularWorkday
+	case info.Holiday != nil:
+		info.Kind = DayKindHoliday
+	case info.Weekend:
+		info.Kind = DayKindWeekend
+	default:
+		info.Kind = DayKindTransferredOff
+	}
+	return info
+}
+
+// Classify describes the day of the calendar. Shortened days are marked
+// only if the calendar was requested with Pre.
+func (cal *Calendar) Classify(t time.Time) (DayInfo, error) {
+	day, err := cal.At(t)
+	if err != nil {
+		return DayInfo{}, err
+	}
+	return classify(t, day, cal.CountryCode, cal.SixDayWeek), nil
+}
+
+// Classify describes the day: whether it is an ordinary working day or weekend,
+// a public holiday, a day off by transfer, a working day moved to a weekend or a shortened
+// pre-holiday day. Holidays are looked up in the built-in catalogue of the country, so a
+// weekday off missing from it, e.g. an observed US holiday or a lunar feast of a year
+// not in the catalogue, is reported as DayKindTransferredOff.
+// The date is taken in the location of t. Shortened days are recognized only if params.Pre is set.
+func (c *Client) Classify(t time.Time, params Params) (DayInfo, error) {
+	return c.ClassifyContext(context.Background(), t, params)
+}
+
+// ClassifyContext describes the day using the provided context
+func (c *Client) ClassifyContext(ctx context.Context, t time.Time, params Params) (DayInfo, error) {
+	return Classify(ctx, c, t, params)
+}
+
+// Classify describes the day using the provider, see Client.Classify
+func Classify(ctx context.Context, p Provider, t time.Time, params Params) (DayInfo, error) {
+	params = resolveParams(p, params)
+	month := t.Month()
+	day := t.Day()
+	params.Year = t.Year()
+	params.Month = &month
+	params.Day = &day
+
+	days, err := p.GetByContext(ctx, params)
+	if days == nil {
+		return DayInfo{}, err
+	}
+	if len(days) != 1 {
+		return DayInfo{}, fmt.Errorf("unexpected number of days for %s: %d", t.Format(time.DateOnly), len(days))
+	}
+	key := params.yearKey(params.Year)
+	return classify(t, days[0], key.CountryCode, key.SixDayWeek), err
+}
+
+// Annotate classifies days returned by GetBy or GetByPeriod, the first of which is start.
+// CountryCode and SixDayWeek of params should match the request.
+func Annotate(start time.Time, days []DayType, params Params) []DayInfo {
+	key := params.yearKey(start.Year())
+	result := make([]DayInfo, len(days))
+	for i, day := range days {
+		result[i] = classify(dateOf(start).AddDate(0, 0, i), day, key.CountryCode, key.SixDayWeek)
+	}
+	return result
+}
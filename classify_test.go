@@ -0,0 +1,120 @@
+package isdayoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCalendarClassify(t *testing.T) {
+	client := newTestClient(t, fixtureHandler(t))
+	cc := CountryCodeRussia
+	pre := true
+	cal, err := client.Calendar(2024, Params{CountryCode: &cc, Pre: &pre})
+	if err != nil {
+		t.Fatalf("Calendar(2024) failed: %v", err)
+	}
+
+	tests := []struct {
+		date    time.Time
+		kind    DayKind
+		weekend bool
+	}{
+		{date(2024, time.January, 1), DayKindHoliday, false},
+		{date(2024, time.January, 6), DayKindHoliday, true},
+		{date(2024, time.January, 13), DayKindWeekend, true},
+		{date(2024, time.January, 9), DayKindRegularWorkday, false},
+		{date(2024, time.February, 22), DayKindShortenedPreHoliday, false},
+		{date(2024, time.February, 23), DayKindHoliday, false},
+		{date(2024, time.April, 27), DayKindTransferredWorkday, true},
+		{date(2024, time.April, 29), DayKindTransferredOff, false},
+		{date(2024, time.November, 2), DayKindShortenedPreHoliday, true},
+		{date(2024, time.November, 4), DayKindHoliday, false},
+		{date(2024, time.December, 28), DayKindTransferredWorkday, true},
+		{date(2024, time.December, 30), DayKindTransferredOff, false},
+	}
+	for _, tt := range tests {
+		info, err := cal.Classify(tt.date)
+		if err != nil {
+			t.Fatalf("Classify(%s) failed: %v", tt.date.Format(time.DateOnly), err)
+		}
+		if info.Kind != tt.kind || info.Weekend != tt.weekend || !info.Date.Equal(tt.date) {
+			t.Errorf("Classify(%s) = %v (weekend %v), expected %v (weekend %v)",
+				tt.date.Format(time.DateOnly), info.Kind, info.Weekend, tt.kind, tt.weekend)
+		}
+	}
+
+	victory, err := cal.Classify(date(2024, time.May, 9))
+	if err != nil || victory.Holiday == nil || victory.Holiday.NameRU != "День Победы" || victory.Holiday.NameEN != "Victory Day" {
+		t.Errorf("Classify(2024-05-09) = %+v, %v; expected Victory Day", victory, err)
+	}
+	if _, err := cal.Classify(date(2025, time.January, 1)); err == nil {
+		t.Error("Classify() outside of calendar should fail")
+	}
+}
+
+func TestClientClassify(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.holidays["20240308"] = true
+	api.holidays["20240312"] = true
+	api.codes["20240307"] = '2'
+	api.codes["20240309"] = '0'
+
+	msk := time.FixedZone("MSK", 3*60*60)
+	sd := true
+	tests := []struct {
+		name   string
+		date   time.Time
+		params Params
+		kind   DayKind
+	}{
+		{"holiday", date(2024, time.March, 8), Params{}, DayKindHoliday},
+		{"shortened", date(2024, time.March, 7), Params{}, DayKindShortenedPreHoliday},
+		{"working saturday", date(2024, time.March, 9), Params{}, DayKindTransferredWorkday},
+		{"sunday", date(2024, time.March, 10), Params{}, DayKindWeekend},
+		{"monday", date(2024, time.March, 11), Params{}, DayKindRegularWorkday},
+		{"weekday off", date(2024, time.March, 12), Params{}, DayKindTransferredOff},
+		{"saturday of six-day week", date(2024, time.March, 16), Params{SixDayWeek: &sd}, DayKindRegularWorkday},
+		{"sunday of six-day week", date(2024, time.March, 17), Params{SixDayWeek: &sd}, DayKindWeekend},
+		// 8 марта 01:00 по Москве — это 7 марта по UTC, но дата берётся в часовом поясе t
+		{"date in own location", time.Date(2024, time.March, 8, 1, 0, 0, 0, msk), Params{}, DayKindHoliday},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			info, err := client.Classify(tt.date, tt.params)
+			if err != nil {
+				t.Fatalf("Classify() failed: %v", err)
+			}
+			if info.Kind != tt.kind {
+				t.Errorf("Classify(%s) = %v, expected %v", tt.date.Format(time.DateOnly), info.Kind, tt.kind)
+			}
+		})
+	}
+}
+
+func TestAnnotate(t *testing.T) {
+	cc := CountryCodeKazakhstan
+	days := []DayType{DayTypeNonWorking, DayTypeNonWorking, DayTypeNonWorking, DayTypeNonWorking, DayTypeWorking}
+	infos := Annotate(date(2024, time.March, 21), days, Params{CountryCode: &cc})
+
+	expected := []DayKind{DayKindHoliday, DayKindHoliday, DayKindHoliday, DayKindWeekend, DayKindRegularWorkday}
+	for i, info := range infos {
+		if info.Kind != expected[i] {
+			t.Errorf("Annotate()[%d] = %v, expected %v", i, info.Kind, expected[i])
+		}
+	}
+	if infos[0].Holiday == nil || infos[0].Holiday.NameEN != "Nauryz" {
+		t.Errorf("Annotate()[0].Holiday = %+v, expected Nauryz", infos[0].Holiday)
+	}
+	if !infos[4].Date.Equal(date(2024, time.March, 25)) {
+		t.Errorf("Annotate()[4].Date = %v", infos[4].Date)
+	}
+}
+
+func TestDayKindString(t *testing.T) {
+	if got := DayKindTransferredWorkday.String(); got != "transferred workday" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := DayKind(42).String(); got != "DayKind(42)" {
+		t.Errorf("String() of unknown kind = %q", got)
+	}
+}
@@ -0,0 +1,25 @@
+package isdayoff
+
+import "time"
+
+// Clock provides current time and timers to the client.
+// It is replaced in tests to make time-dependent behaviour deterministic.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time                         { return time.Now() }
+func (systemClock) After(d time.Duration) <-chan time.Time { return time.After(d) }
+
+// WithClock sets clock used for rate limiting and retry delays.
+// Caches take their own clock, see WithCacheClock.
+func WithClock(clock Clock) Option {
+	return func(c *Client) {
+		if clock != nil {
+			c.clock = clock
+		}
+	}
+}
@@ -0,0 +1,68 @@
+// Command isdayoff-server serves production calendars with the same HTTP API
+// as isdayoff.ru, so that services can use an internal endpoint instead.
+//
+// Usage:
+//
+//	isdayoff-server [-addr :8080] [-upstream https://isdayoff.ru] [-cache-dir DIR] [-ttl 24h] [-offline]
+//
+// By default years are fetched from the upstream API and kept in memory.
+// With -cache-dir they are also stored on disk and survive restarts; stale
+// data is served while the upstream is unreachable. With -offline the server
+// answers only from -cache-dir and never makes requests.
+package main
+
+import (
+	"errors"
+	"flag"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+	"github.com/kotopheiop/isdayoff/server"
+)
+
+func main() {
+	addr := flag.String("addr", ":8080", "address to listen on")
+	upstream := flag.String("upstream", isdayoff.DefaultBaseURL, "base URL of the upstream API")
+	cacheDir := flag.String("cache-dir", "", "directory to store year calendars in")
+	ttl := flag.Duration("ttl", 24*time.Hour, "time after which cached years are refreshed")
+	offline := flag.Bool("offline", false, "answer only from -cache-dir without upstream requests")
+	flag.Parse()
+
+	src, err := source(*upstream, *cacheDir, *ttl, *offline)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	log.Printf("listening on %s", *addr)
+	log.Fatal(http.ListenAndServe(*addr, server.New(src)))
+}
+
+// source builds the data source of the server from the flags
+func source(upstream, cacheDir string, ttl time.Duration, offline bool) (server.Source, error) {
+	if offline {
+		if cacheDir == "" {
+			return nil, errors.New("-offline requires -cache-dir")
+		}
+		files, err := isdayoff.NewFileCache(cacheDir, 0)
+		if err != nil {
+			return nil, err
+		}
+		return server.CacheSource(files), nil
+	}
+
+	opts := []isdayoff.Option{
+		isdayoff.WithBaseURL(upstream),
+		isdayoff.WithRetry(isdayoff.DefaultRetryPolicy()),
+		isdayoff.WithCache(isdayoff.NewMemoryCache(ttl, 0)),
+	}
+	if cacheDir != "" {
+		files, err := isdayoff.NewFileCache(cacheDir, ttl)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, isdayoff.WithCache(files))
+	}
+	return server.ClientSource(isdayoff.New(opts...)), nil
+}
@@ -0,0 +1,305 @@
+// Command isdayoff queries the isdayoff.ru production calendar.
+//
+// Usage:
+//
+//	isdayoff [flags] command [args]
+//
+// Commands:
+//
+//	today                 type of today
+//	tomorrow              type of tomorrow
+//	date YYYY-MM-DD       type of the date
+//	month YYYY-MM         types of days of the month
+//	year YYYY             types of days of the year
+//	range FROM TO         types of days from one date to another inclusive
+//	leap YYYY             whether the year is leap
+//
+// Commands for a single day exit with 0 for a working day (including shortened
+// and COVID-19 working days) and 1 for a day off, so they can be used in shell
+// conditions:
+//
+//	if isdayoff --cc kz tomorrow >/dev/null; then echo "work tomorrow"; fi
+//
+// leap exits with 0 for a leap year and 1 otherwise. Other commands exit with 0
+// on success. Invalid usage, including invalid flag values, exits with 2,
+// failed requests with 3.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+const (
+	exitWorking    = 0
+	exitNonWorking = 1
+	exitUsage      = 2
+	exitError      = 3
+)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// errUsage marks errors in command line arguments
+var errUsage = errors.New("usage error")
+
+// day is a day of the output
+type day struct {
+	Date    string           `json:"date"`
+	Type    isdayoff.DayType `json:"type"`
+	Working bool             `json:"working"`
+	Label   string           `json:"label"`
+}
+
+func newDay(date time.Time, t isdayoff.DayType) day {
+	return day{Date: date.Format(time.DateOnly), Type: t, Working: t.IsWorking(), Label: t.String()}
+}
+
+// options are parsed command line flags
+type options struct {
+	params  isdayoff.Params
+	json    bool
+	baseURL string
+	timeout time.Duration
+}
+
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("isdayoff", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "usage: isdayoff [flags] today|tomorrow|date YYYY-MM-DD|month YYYY-MM|year YYYY|range FROM TO|leap YYYY")
+		fs.PrintDefaults()
+	}
+
+	var opts options
+	cc := fs.String("cc", "", "country code: ru, by, kz, ua, us, uz, tr")
+	pre := fs.Bool("pre", false, "mark shortened pre-holiday days with 2")
+	covid := fs.Bool("covid", false, "mark COVID-19 working days with 4")
+	sd := fs.Bool("sd", false, "six-day working week")
+	tz := fs.String("tz", "", "IANA time zone for today and tomorrow")
+	fs.BoolVar(&opts.json, "json", false, "print JSON instead of text")
+	fs.StringVar(&opts.baseURL, "base-url", isdayoff.DefaultBaseURL, "API base URL")
+	fs.DurationVar(&opts.timeout, "timeout", 10*time.Second, "timeout of a request")
+
+	// флаги допускаются в любом месте командной строки
+	var positional []string
+	for {
+		if err := fs.Parse(args); errors.Is(err, flag.ErrHelp) {
+			return exitWorking
+		} else if err != nil {
+			return exitUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+	if len(positional) == 0 {
+		fs.Usage()
+		return exitUsage
+	}
+	command, rest := positional[0], positional[1:]
+
+	fs.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "cc":
+			code := isdayoff.CountryCode(strings.ToLower(*cc))
+			opts.params.CountryCode = &code
+		case "pre":
+			opts.params.Pre = pre
+		case "covid":
+			opts.params.Covid = covid
+		case "sd":
+			opts.params.SixDayWeek = sd
+		case "tz":
+			opts.params.TZ = tz
+		}
+	})
+
+	client := isdayoff.New(isdayoff.WithBaseURL(opts.baseURL), isdayoff.WithTimeout(opts.timeout))
+	code, err := execute(ctx, client, command, rest, opts, stdout)
+	var invalid *isdayoff.ValidationError
+	if errors.Is(err, errUsage) || errors.As(err, &invalid) {
+		fmt.Fprintf(stderr, "isdayoff: %v\n", err)
+		fs.Usage()
+		return exitUsage
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "isdayoff: %v\n", err)
+		return exitError
+	}
+	return code
+}
+
+// execute runs the command and returns the exit code
+func execute(ctx context.Context, client *isdayoff.Client, command string, args []string, opts options, w io.Writer) (int, error) {
+	nargs := map[string]int{"today": 0, "tomorrow": 0, "date": 1, "month": 1, "year": 1, "range": 2, "leap": 1}
+	n, ok := nargs[command]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown command %q", errUsage, command)
+	}
+	if len(args) != n {
+		return 0, fmt.Errorf("%w: %s expects %d arguments, got %d", errUsage, command, n, len(args))
+	}
+
+	switch command {
+	case "today", "tomorrow":
+		offset := map[string]int{"today": 0, "tomorrow": 1}[command]
+		loc, err := location(opts.params)
+		if err != nil {
+			return 0, err
+		}
+		get := client.TodayContext
+		if offset == 1 {
+			get = client.TomorrowContext
+		}
+		t, err := get(ctx, opts.params)
+		if err != nil {
+			return 0, err
+		}
+		return printDay(w, newDay(time.Now().In(loc).AddDate(0, 0, offset), *t), opts.json)
+
+	case "date":
+		date, err := parseDate(args[0])
+		if err != nil {
+			return 0, err
+		}
+		days, err := client.GetByDatesContext(ctx, date, date, opts.params)
+		if err != nil {
+			return 0, err
+		}
+		return printDay(w, newDay(date.Time(), days[0]), opts.json)
+
+	case "month":
+		first, err := time.Parse("2006-01", args[0])
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid month %q, expected YYYY-MM", errUsage, args[0])
+		}
+		params := opts.params
+		month := first.Month()
+		params.Year = first.Year()
+		params.Month = &month
+		days, err := client.GetByContext(ctx, params)
+		if err != nil {
+			return 0, err
+		}
+		return exitWorking, printDays(w, first, days, opts.json)
+
+	case "year":
+		year, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid year %q", errUsage, args[0])
+		}
+		params := opts.params
+		params.Year = year
+		days, err := client.GetByContext(ctx, params)
+		if err != nil {
+			return 0, err
+		}
+		return exitWorking, printDays(w, time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC), days, opts.json)
+
+	case "range":
+		from, err := parseDate(args[0])
+		if err != nil {
+			return 0, err
+		}
+		to, err := parseDate(args[1])
+		if err != nil {
+			return 0, err
+		}
+		days, err := client.GetRangeContext(ctx, from.Time(), to.Time(), opts.params)
+		if err != nil {
+			return 0, err
+		}
+		return exitWorking, printDays(w, from.Time(), days, opts.json)
+
+	default: // leap
+		year, err := strconv.Atoi(args[0])
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid year %q", errUsage, args[0])
+		}
+		leap, err := client.IsLeapContext(ctx, year)
+		if err != nil {
+			return 0, err
+		}
+		if opts.json {
+			err = json.NewEncoder(w).Encode(map[string]any{"year": year, "leap": leap})
+		} else {
+			_, err = fmt.Fprintln(w, leap)
+		}
+		if err != nil {
+			return 0, err
+		}
+		if !leap {
+			return exitNonWorking, nil
+		}
+		return exitWorking, nil
+	}
+}
+
+// parseDate parses a date argument
+func parseDate(s string) (isdayoff.Date, error) {
+	date, err := isdayoff.ParseDate(s)
+	if err != nil {
+		return isdayoff.Date{}, fmt.Errorf("%w: %v", errUsage, err)
+	}
+	return date, nil
+}
+
+// location returns the time zone today and tomorrow are computed in by the API
+func location(params isdayoff.Params) (*time.Location, error) {
+	if params.TZ == nil {
+		return time.LoadLocation("Europe/Moscow")
+	}
+	loc, err := time.LoadLocation(*params.TZ)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid time zone %q", errUsage, *params.TZ)
+	}
+	return loc, nil
+}
+
+// printDay prints a single day and returns the exit code for it
+func printDay(w io.Writer, d day, asJSON bool) (int, error) {
+	var err error
+	if asJSON {
+		err = json.NewEncoder(w).Encode(d)
+	} else {
+		_, err = fmt.Fprintf(w, "%s\t%s\t%s\n", d.Date, string(d.Type), d.Label)
+	}
+	if err != nil {
+		return 0, err
+	}
+	if d.Working {
+		return exitWorking, nil
+	}
+	return exitNonWorking, nil
+}
+
+// printDays prints consecutive days starting at start
+func printDays(w io.Writer, start time.Time, types []isdayoff.DayType, asJSON bool) error {
+	days := make([]day, len(types))
+	for i, t := range types {
+		days[i] = newDay(start.AddDate(0, 0, i), t)
+	}
+	if asJSON {
+		return json.NewEncoder(w).Encode(days)
+	}
+	for _, d := range days {
+		if _, err := fmt.Fprintf(w, "%s\t%s\t%s\n", d.Date, string(d.Type), d.Label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
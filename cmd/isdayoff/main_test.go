@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// fakeAPI отвечает фиксированными данными и запоминает запросы
+type fakeAPI struct {
+	mu      sync.Mutex
+	queries []string
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.queries = append(a.queries, r.URL.Path+"?"+r.URL.RawQuery)
+	a.mu.Unlock()
+
+	q := r.URL.Query()
+	switch {
+	case r.URL.Path == "/api/isleap":
+		w.Write([]byte(map[bool]string{true: "1", false: "0"}[q.Get("year") == "2024"]))
+	case r.URL.Path == "/today", r.URL.Path == "/tomorrow":
+		w.Write([]byte(map[bool]string{true: "0", false: "1"}[r.URL.Path == "/today"]))
+	case q.Get("date1") == "20240509":
+		w.Write([]byte("1"))
+	case q.Get("date1") == "20240510":
+		w.Write([]byte("0"))
+	case q.Get("date1") != "":
+		w.Write([]byte("0112"))
+	case q.Get("month") == "02":
+		w.Write([]byte(strings.Repeat("0", 29)))
+	case q.Get("year") == "2023":
+		w.Write([]byte(strings.Repeat("0", 365)))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("101"))
+	}
+}
+
+func runCLI(t *testing.T, args ...string) (code int, stdout, stderr string, api *fakeAPI) {
+	t.Helper()
+	api = &fakeAPI{}
+	srv := httptest.NewServer(api)
+	t.Cleanup(srv.Close)
+
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), append([]string{"--base-url", srv.URL}, args...), &out, &errOut)
+	return code, out.String(), errOut.String(), api
+}
+
+func TestExitCodes(t *testing.T) {
+	tests := []struct {
+		args []string
+		code int
+	}{
+		{[]string{"date", "2024-05-09"}, exitNonWorking},
+		{[]string{"date", "20240510"}, exitWorking},
+		{[]string{"today"}, exitWorking},
+		{[]string{"tomorrow"}, exitNonWorking},
+		{[]string{"leap", "2024"}, exitWorking},
+		{[]string{"leap", "2023"}, exitNonWorking},
+		{[]string{"month", "2024-02"}, exitWorking},
+		{[]string{"year", "2023"}, exitWorking},
+		{[]string{"range", "2024-01-01", "2024-01-04"}, exitWorking},
+		{[]string{}, exitUsage},
+		{[]string{"yesterday"}, exitUsage},
+		{[]string{"date"}, exitUsage},
+		{[]string{"date", "2024-02-30"}, exitUsage},
+		{[]string{"month", "2024"}, exitUsage},
+		{[]string{"--unknown", "today"}, exitUsage},
+		{[]string{"--cc", "xx", "today"}, exitUsage},
+		{[]string{"--tz", "Mars/Olympus", "date", "2024-05-09"}, exitUsage},
+		{[]string{"year", "2022"}, exitError},
+	}
+	for _, tt := range tests {
+		t.Run(strings.Join(tt.args, " "), func(t *testing.T) {
+			code, stdout, stderr, _ := runCLI(t, tt.args...)
+			if code != tt.code {
+				t.Errorf("exit code = %d, expected %d\nstdout: %s\nstderr: %s", code, tt.code, stdout, stderr)
+			}
+		})
+	}
+}
+
+func TestFlags(t *testing.T) {
+	// флаги можно указывать и после команды
+	code, _, stderr, api := runCLI(t, "--cc", "kz", "--pre", "tomorrow", "--covid", "--sd=false", "--tz", "Asia/Almaty")
+	if code != exitNonWorking {
+		t.Fatalf("exit code = %d: %s", code, stderr)
+	}
+	expected := "/tomorrow?cc=kz&covid=1&pre=1&sd=0&tz=Asia%2FAlmaty"
+	if len(api.queries) != 1 || api.queries[0] != expected {
+		t.Errorf("requests = %v, expected %s", api.queries, expected)
+	}
+
+	// код страны не зависит от регистра
+	code, _, stderr, api = runCLI(t, "--cc", "KZ", "tomorrow")
+	if code != exitNonWorking || len(api.queries) != 1 || api.queries[0] != "/tomorrow?cc=kz" {
+		t.Errorf("--cc KZ: exit code = %d, requests = %v: %s", code, api.queries, stderr)
+	}
+
+	// неуказанные флаги не передаются в API
+	_, _, _, api = runCLI(t, "date", "2024-05-10")
+	if api.queries[0] != "/api/getdata?date1=20240510&date2=20240510" {
+		t.Errorf("request = %s", api.queries[0])
+	}
+}
+
+func TestOutput(t *testing.T) {
+	_, stdout, _, _ := runCLI(t, "range", "2024-01-01", "2024-01-04")
+	expected := "2024-01-01\t0\tworking\n2024-01-02\t1\tnon-working\n2024-01-03\t1\tnon-working\n2024-01-04\t2\tshortened\n"
+	if stdout != expected {
+		t.Errorf("text output = %q, expected %q", stdout, expected)
+	}
+
+	_, stdout, _, _ = runCLI(t, "--json", "date", "2024-05-09")
+	var d day
+	if err := json.Unmarshal([]byte(stdout), &d); err != nil {
+		t.Fatalf("invalid JSON %q: %v", stdout, err)
+	}
+	if d.Date != "2024-05-09" || d.Type != "1" || d.Working || d.Label != "non-working" {
+		t.Errorf("JSON output = %+v", d)
+	}
+
+	_, stdout, _, _ = runCLI(t, "month", "2024-02", "--json")
+	var days []day
+	if err := json.Unmarshal([]byte(stdout), &days); err != nil || len(days) != 29 || days[28].Date != "2024-02-29" {
+		t.Errorf("JSON output for month = %s, %v", stdout, err)
+	}
+
+	_, stdout, _, _ = runCLI(t, "--json", "leap", "2024")
+	if strings.TrimSpace(stdout) != `{"leap":true,"year":2024}` {
+		t.Errorf("JSON output for leap = %s", stdout)
+	}
+}
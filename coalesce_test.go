@@ -0,0 +1,160 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// waitForFlightWaiters blocks until n callers share in-flight requests of the client
+func waitForFlightWaiters(t *testing.T, c *Client, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.flights.mu.Lock()
+		got := 0
+		for _, f := range c.flights.calls {
+			got += f.waiters
+		}
+		c.flights.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d callers", n)
+}
+
+func gatedHandler(calls *atomic.Int32, release <-chan struct{}, status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		<-release
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+}
+
+func TestCoalesceIdenticalRequests(t *testing.T) {
+	const callers = 200
+	var calls atomic.Int32
+	release := make(chan struct{})
+	client := newTestClient(t, gatedHandler(&calls, release, http.StatusOK, "1"))
+
+	cc := CountryCodeRussia
+	results := make([]*DayType, callers)
+	errs := make([]error, callers)
+	var wg sync.WaitGroup
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], errs[i] = client.Today(Params{CountryCode: &cc})
+		}()
+	}
+
+	waitForFlightWaiters(t, client, callers)
+	close(release)
+	wg.Wait()
+
+	if calls.Load() != 1 {
+		t.Errorf("server got %d requests, expected 1", calls.Load())
+	}
+	for i := range callers {
+		if errs[i] != nil || results[i] == nil || *results[i] != DayTypeNonWorking {
+			t.Fatalf("caller %d got %v, %v", i, results[i], errs[i])
+		}
+	}
+
+	// после завершения запрос выполняется заново
+	if _, err := client.Today(Params{CountryCode: &cc}); err != nil {
+		t.Fatalf("Today() failed: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server got %d requests, expected finished request not to be reused", calls.Load())
+	}
+}
+
+func TestCoalesceSharesErrors(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	client := newTestClient(t, gatedHandler(&calls, release, http.StatusInternalServerError, "199"))
+
+	errs := make(chan error, 10)
+	for range 10 {
+		go func() {
+			_, err := client.GetBy(Params{Year: 2024})
+			errs <- err
+		}()
+	}
+	waitForFlightWaiters(t, client, 10)
+	close(release)
+	for range 10 {
+		var apiErr *APIError
+		if err := <-errs; !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeInternalError {
+			t.Errorf("GetBy() error = %v, expected shared ErrorCodeInternalError", err)
+		}
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server got %d requests, expected 1", calls.Load())
+	}
+}
+
+func TestCoalesceDistinctRequests(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	client := newTestClient(t, gatedHandler(&calls, release, http.StatusOK, "0"))
+
+	kz := CountryCodeKazakhstan
+	var wg sync.WaitGroup
+	for _, params := range []Params{{}, {CountryCode: &kz}} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			client.Tomorrow(params)
+		}()
+	}
+	waitForFlightWaiters(t, client, 2)
+	close(release)
+	wg.Wait()
+	if calls.Load() != 2 {
+		t.Errorf("server got %d requests, expected requests with different query not to be merged", calls.Load())
+	}
+}
+
+func TestCoalesceCallerCancellation(t *testing.T) {
+	var calls atomic.Int32
+	release := make(chan struct{})
+	client := newTestClient(t, gatedHandler(&calls, release, http.StatusOK, "0"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error, 1)
+	go func() {
+		_, err := client.TodayContext(ctx, Params{})
+		cancelled <- err
+	}()
+	waitForFlightWaiters(t, client, 1)
+
+	patient := make(chan error, 1)
+	go func() {
+		_, err := client.Today(Params{})
+		patient <- err
+	}()
+	waitForFlightWaiters(t, client, 2)
+
+	// отмена одного вызывающего не прерывает общий запрос
+	cancel()
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("TodayContext() error = %v, expected context.Canceled", err)
+	}
+	close(release)
+	if err := <-patient; err != nil {
+		t.Errorf("Today() of the remaining caller failed: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Errorf("server got %d requests, expected 1", calls.Load())
+	}
+}
@@ -0,0 +1,124 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newTestClient(t *testing.T, handler http.Handler, opts ...Option) *Client {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return New(append([]Option{WithBaseURL(srv.URL)}, opts...)...)
+}
+
+// blockingHandler держит запрос, пока клиент не отменит его, и сообщает об отмене
+func blockingHandler(aborted chan<- struct{}) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+			close(aborted)
+		case <-time.After(5 * time.Second):
+			w.Write([]byte("0"))
+		}
+	})
+}
+
+func TestContextCancellation(t *testing.T) {
+	calls := []struct {
+		name string
+		call func(ctx context.Context, c *Client) error
+	}{
+		{"IsLeapContext", func(ctx context.Context, c *Client) error {
+			_, err := c.IsLeapContext(ctx, 2024)
+			return err
+		}},
+		{"GetByContext", func(ctx context.Context, c *Client) error {
+			_, err := c.GetByContext(ctx, Params{Year: 2024})
+			return err
+		}},
+		{"GetByPeriodContext", func(ctx context.Context, c *Client) error {
+			_, err := c.GetByPeriodContext(ctx, "20240101", "20240107", Params{})
+			return err
+		}},
+		{"TodayContext", func(ctx context.Context, c *Client) error {
+			_, err := c.TodayContext(ctx, Params{})
+			return err
+		}},
+		{"TomorrowContext", func(ctx context.Context, c *Client) error {
+			_, err := c.TomorrowContext(ctx, Params{})
+			return err
+		}},
+	}
+
+	for _, tt := range calls {
+		t.Run(tt.name, func(t *testing.T) {
+			aborted := make(chan struct{})
+			client := newTestClient(t, blockingHandler(aborted))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				time.Sleep(50 * time.Millisecond)
+				cancel()
+			}()
+
+			err := tt.call(ctx, client)
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("%s() error = %v, expected context.Canceled", tt.name, err)
+			}
+			select {
+			case <-aborted:
+			case <-time.After(2 * time.Second):
+				t.Errorf("%s() did not abort in-flight request on the server", tt.name)
+			}
+		})
+	}
+}
+
+func TestContextDeadline(t *testing.T) {
+	aborted := make(chan struct{})
+	client := newTestClient(t, blockingHandler(aborted))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetByContext(ctx, Params{Year: 2024})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("GetByContext() error = %v, expected context.DeadlineExceeded", err)
+	}
+}
+
+type ctxKey struct{}
+
+type valueCheckTransport struct {
+	next http.RoundTripper
+	seen any
+}
+
+func (vt *valueCheckTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	vt.seen = req.Context().Value(ctxKey{})
+	return vt.next.RoundTrip(req)
+}
+
+func TestContextValues(t *testing.T) {
+	vt := &valueCheckTransport{next: http.DefaultTransport}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("1"))
+	}), WithHTTPClient(&http.Client{Transport: vt}))
+
+	ctx := context.WithValue(context.Background(), ctxKey{}, "trace-42")
+	day, err := client.TodayContext(ctx, Params{})
+	if err != nil {
+		t.Fatalf("TodayContext() failed: %v", err)
+	}
+	if *day != DayTypeNonWorking {
+		t.Errorf("TodayContext() = %v, expected %v", *day, DayTypeNonWorking)
+	}
+	if vt.seen != "trace-42" {
+		t.Errorf("context value was not propagated to transport, got %v", vt.seen)
+	}
+}
@@ -0,0 +1,124 @@
+package isdayoff
+
+import (
+	"context"
+	"time"
+)
+
+// CountOption changes boundaries of the interval days are counted in
+type CountOption func(*countOptions)
+
+type countOptions struct {
+	excludeFrom bool
+	excludeTo   bool
+}
+
+// ExcludeFrom does not count the first day of the interval
+func ExcludeFrom() CountOption {
+	return func(o *countOptions) {
+		o.excludeFrom = true
+	}
+}
+
+// ExcludeTo does not count the last day of the interval
+func ExcludeTo() CountOption {
+	return func(o *countOptions) {
+		o.excludeTo = true
+	}
+}
+
+// count counts days of each type in the interval, fetching one year calendar per API request
+func (w *workdays) count(from, to time.Time, opts []CountOption) (map[DayType]int, error) {
+	var o countOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	from, to = dateOf(from), dateOf(to)
+	if err := validateOrder(from, to); err != nil {
+		return nil, err
+	}
+	if o.excludeFrom {
+		from = from.AddDate(0, 0, 1)
+	}
+	if o.excludeTo {
+		to = to.AddDate(0, 0, -1)
+	}
+
+	result := map[DayType]int{}
+	for year := from.Year(); year <= to.Year() && !to.Before(from); year++ {
+		cal, err := w.calendar(year)
+		if err != nil {
+			return nil, err
+		}
+		days, err := cal.Range(later(from, cal.Start), earlier(to, cal.End()))
+		if err != nil {
+			return nil, err
+		}
+		for _, day := range days {
+			result[day]++
+		}
+	}
+	return result, w.stale
+}
+
+func later(a, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+	return b
+}
+
+func earlier(a, b time.Time) time.Time {
+	if a.Before(b) {
+		return a
+	}
+	return b
+}
+
+// CountDays counts days of each type from one date to another, both included by default.
+// The interval may be of any length: calendars are fetched one year per request.
+func (c *Client) CountDays(from, to time.Time, params Params, opts ...CountOption) (map[DayType]int, error) {
+	return c.CountDaysContext(context.Background(), from, to, params, opts...)
+}
+
+// CountDaysContext counts days of each type using the provided context
+func (c *Client) CountDaysContext(ctx context.Context, from, to time.Time, params Params, opts ...CountOption) (map[DayType]int, error) {
+	return CountDays(ctx, c, from, to, params, opts...)
+}
+
+// CountWorkingDays counts working days from one date to another, both included by default.
+// Shortened pre-holiday days (DayTypeHalfHoliday) and days marked for COVID-19
+// (DayTypeWorkingCovid) are counted as working; use CountDays to tell them apart.
+func (c *Client) CountWorkingDays(from, to time.Time, params Params, opts ...CountOption) (int, error) {
+	return c.CountWorkingDaysContext(context.Background(), from, to, params, opts...)
+}
+
+// CountWorkingDaysContext counts working days using the provided context
+func (c *Client) CountWorkingDaysContext(ctx context.Context, from, to time.Time, params Params, opts ...CountOption) (int, error) {
+	return CountWorkingDays(ctx, c, from, to, params, opts...)
+}
+
+// CountDays counts days of each type using the provider, see Client.CountDays
+func CountDays(ctx context.Context, p Provider, from, to time.Time, params Params, opts ...CountOption) (map[DayType]int, error) {
+	return newWorkdays(ctx, p, params).count(from, to, opts)
+}
+
+// CountWorkingDays counts working days using the provider, see Client.CountWorkingDays
+func CountWorkingDays(ctx context.Context, p Provider, from, to time.Time, params Params, opts ...CountOption) (int, error) {
+	counts, err := CountDays(ctx, p, from, to, params, opts...)
+	if counts == nil {
+		return 0, err
+	}
+	return workingIn(counts), err
+}
+
+// workingIn sums counts of working day types
+func workingIn(counts map[DayType]int) int {
+	total := 0
+	for day, n := range counts {
+		if day.IsWorking() {
+			total += n
+		}
+	}
+	return total
+}
@@ -0,0 +1,107 @@
+package isdayoff
+
+import (
+	"maps"
+	"testing"
+	"time"
+)
+
+func TestCountDays(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.holidays["20240308"] = true
+	api.codes["20240307"] = '2'
+	api.codes["20200406"] = '4'
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		to       time.Time
+		opts     []CountOption
+		expected map[DayType]int
+		working  int
+	}{
+		{
+			name:     "single working day",
+			from:     date(2024, time.March, 4),
+			to:       date(2024, time.March, 4),
+			expected: map[DayType]int{DayTypeWorking: 1},
+			working:  1,
+		},
+		{
+			name:     "week with shortened day and holiday",
+			from:     date(2024, time.March, 4),
+			to:       date(2024, time.March, 10),
+			expected: map[DayType]int{DayTypeWorking: 3, DayTypeHalfHoliday: 1, DayTypeNonWorking: 3},
+			working:  4,
+		},
+		{
+			name:     "exclusive bounds",
+			from:     date(2024, time.March, 4),
+			to:       date(2024, time.March, 8),
+			opts:     []CountOption{ExcludeFrom(), ExcludeTo()},
+			expected: map[DayType]int{DayTypeWorking: 2, DayTypeHalfHoliday: 1},
+			working:  3,
+		},
+		{
+			name:     "empty interval",
+			from:     date(2024, time.March, 4),
+			to:       date(2024, time.March, 4),
+			opts:     []CountOption{ExcludeTo()},
+			expected: map[DayType]int{},
+			working:  0,
+		},
+		{
+			name:     "covid day counts as working",
+			from:     date(2020, time.April, 6),
+			to:       date(2020, time.April, 12),
+			expected: map[DayType]int{DayTypeWorking: 4, DayTypeWorkingCovid: 1, DayTypeNonWorking: 2},
+			working:  5,
+		},
+		{
+			name:     "several years",
+			from:     date(2019, time.January, 1),
+			to:       date(2024, time.December, 31),
+			expected: map[DayType]int{DayTypeWorking: 1563, DayTypeNonWorking: 627, DayTypeHalfHoliday: 1, DayTypeWorkingCovid: 1},
+			working:  1565,
+		},
+		{
+			name:     "time of day is ignored",
+			from:     time.Date(2024, time.March, 4, 23, 0, 0, 0, time.UTC),
+			to:       time.Date(2024, time.March, 5, 1, 0, 0, 0, time.UTC),
+			expected: map[DayType]int{DayTypeWorking: 2},
+			working:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts, err := client.CountDays(tt.from, tt.to, Params{}, tt.opts...)
+			if err != nil {
+				t.Fatalf("CountDays() failed: %v", err)
+			}
+			if !maps.Equal(counts, tt.expected) {
+				t.Errorf("CountDays() = %v, expected %v", counts, tt.expected)
+			}
+			working, err := client.CountWorkingDays(tt.from, tt.to, Params{}, tt.opts...)
+			if err != nil {
+				t.Fatalf("CountWorkingDays() failed: %v", err)
+			}
+			if working != tt.working {
+				t.Errorf("CountWorkingDays() = %d, expected %d", working, tt.working)
+			}
+		})
+	}
+
+	if _, err := client.CountWorkingDays(date(2024, time.March, 5), date(2024, time.March, 4), Params{}); err == nil {
+		t.Error("CountWorkingDays() with reversed dates should fail")
+	}
+}
+
+func TestCountDaysRequestsPerYear(t *testing.T) {
+	api, client := newFakeAPI(t)
+	if _, err := client.CountWorkingDays(date(2020, time.June, 1), date(2023, time.February, 1), Params{}); err != nil {
+		t.Fatalf("CountWorkingDays() failed: %v", err)
+	}
+	if got := api.requestCount(); got != 4 {
+		t.Errorf("server got %d requests, expected one per year: %v", got, api.requests)
+	}
+}
@@ -0,0 +1,125 @@
+package isdayoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Date is a calendar date without time of day and location
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// NewDate returns the date. Use Valid to check that it exists.
+func NewDate(year int, month time.Month, day int) Date {
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// DateOf returns the calendar date of t in its location
+func DateOf(t time.Time) Date {
+	return Date{Year: t.Year(), Month: t.Month(), Day: t.Day()}
+}
+
+// ParseDate parses date in YYYYMMDD or YYYY-MM-DD format
+func ParseDate(s string) (Date, error) {
+	return parseDate("date", s)
+}
+
+// parseDate parses date reporting errors for the named field
+func parseDate(field, s string) (Date, error) {
+	for _, layout := range []string{periodLayout, time.DateOnly} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return DateOf(t), nil
+		}
+	}
+	return Date{}, &ValidationError{Field: field, Value: s, Reason: "expected existing date in YYYYMMDD or YYYY-MM-DD format"}
+}
+
+// Valid reports whether the date exists
+func (d Date) Valid() bool {
+	return d.Year >= 1 && d.Year <= 9999 && DateOf(d.Time()) == d
+}
+
+// Time returns midnight UTC of the date
+func (d Date) Time() time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, time.UTC)
+}
+
+// Format formats the date with time.Time layout
+func (d Date) Format(layout string) string {
+	return d.Time().Format(layout)
+}
+
+// String returns the date in YYYY-MM-DD format
+func (d Date) String() string {
+	return fmt.Sprintf("%04d-%02d-%02d", d.Year, d.Month, d.Day)
+}
+
+// Before reports whether d is before other
+func (d Date) Before(other Date) bool {
+	return d.Time().Before(other.Time())
+}
+
+// AddDays returns the date n days after d
+func (d Date) AddDays(n int) Date {
+	return DateOf(d.Time().AddDate(0, 0, n))
+}
+
+// validatePeriod checks dates of a single period request
+func validatePeriod(from, to Date) error {
+	if !from.Valid() {
+		return &ValidationError{Field: "date1", Value: from, Reason: "date does not exist"}
+	}
+	if !to.Valid() {
+		return &ValidationError{Field: "date2", Value: to, Reason: "date does not exist"}
+	}
+	if to.Before(from) {
+		return &ValidationError{Field: "date2", Value: to, Reason: fmt.Sprintf("before date1 %v", from)}
+	}
+	if n := daysBetween(from.Time(), to.Time()) + 1; n > maxPeriodDays {
+		return &ValidationError{Field: "date2", Value: to, Reason: fmt.Sprintf("period of %d days exceeds %d days, use GetRange", n, maxPeriodDays)}
+	}
+	return nil
+}
+
+// validateOrder checks that an interval does not end before it starts
+func validateOrder(from, to time.Time) error {
+	if dateOf(to).Before(dateOf(from)) {
+		return &ValidationError{Field: "to", Value: DateOf(to), Reason: fmt.Sprintf("before start %v", DateOf(from))}
+	}
+	return nil
+}
+
+// GetByDates gets data from one date to another inclusive. Dates are validated locally:
+// both must exist, be in order and span at most 366 days, otherwise ValidationError is returned.
+func (c *Client) GetByDates(from, to Date, params Params) ([]DayType, error) {
+	return c.GetByDatesContext(context.Background(), from, to, params)
+}
+
+// GetByDatesContext gets data from one date to another using the provided context
+func (c *Client) GetByDatesContext(ctx context.Context, from, to Date, params Params) ([]DayType, error) {
+	if err := validatePeriod(from, to); err != nil {
+		return nil, err
+	}
+	params = params.withDefaults(c.defaults)
+	if err := params.validate(false); err != nil {
+		return nil, err
+	}
+	if c.cached() {
+		return c.cachedPeriod(ctx, from, to, params)
+	}
+
+	q := params.values()
+	q.Set("date1", from.Format(periodLayout))
+	q.Set("date2", to.Format(periodLayout))
+
+	body, err := c.get(ctx, "/api/getdata", q)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseDays(body)
+}
@@ -0,0 +1,118 @@
+package isdayoff
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Date
+		valid    bool
+	}{
+		{"20240229", NewDate(2024, time.February, 29), true},
+		{"2024-02-29", NewDate(2024, time.February, 29), true},
+		{"20230229", Date{}, false},
+		{"20241301", Date{}, false},
+		{"2024-2-9", Date{}, false},
+		{"2024022", Date{}, false},
+		{"", Date{}, false},
+	}
+	for _, tt := range tests {
+		got, err := ParseDate(tt.input)
+		if tt.valid {
+			if err != nil || got != tt.expected {
+				t.Errorf("ParseDate(%q) = %v, %v; expected %v", tt.input, got, err, tt.expected)
+			}
+			continue
+		}
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("ParseDate(%q) error = %v, expected ValidationError", tt.input, err)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	d := NewDate(2024, time.December, 31)
+	if d.String() != "2024-12-31" || d.Format(periodLayout) != "20241231" {
+		t.Errorf("Date formatting: %s, %s", d.String(), d.Format(periodLayout))
+	}
+	if next := d.AddDays(1); next != NewDate(2025, time.January, 1) {
+		t.Errorf("AddDays(1) = %v", next)
+	}
+	if !d.Before(d.AddDays(1)) || d.Before(d) {
+		t.Error("Before() returned wrong result")
+	}
+	if got := DateOf(time.Date(2024, time.March, 1, 1, 0, 0, 0, time.FixedZone("MSK", 3*60*60))); got != NewDate(2024, time.March, 1) {
+		t.Errorf("DateOf() = %v, expected date in own location", got)
+	}
+	for _, invalid := range []Date{{2023, time.February, 29}, {2024, time.April, 31}, {2024, 0, 1}, {0, time.January, 1}, {2024, time.January, 0}} {
+		if invalid.Valid() {
+			t.Errorf("%#v.Valid() = true", invalid)
+		}
+	}
+}
+
+func TestGetByDates(t *testing.T) {
+	api, client := newFakeAPI(t)
+
+	days, err := client.GetByDates(NewDate(2024, time.March, 1), NewDate(2024, time.March, 3), Params{})
+	if err != nil {
+		t.Fatalf("GetByDates() failed: %v", err)
+	}
+	if !slices.Equal(days, []DayType{DayTypeWorking, DayTypeNonWorking, DayTypeNonWorking}) {
+		t.Errorf("GetByDates() = %v", days)
+	}
+	if api.requests[0] != "/api/getdata?date1=20240301&date2=20240303" {
+		t.Errorf("GetByDates() requested %s", api.requests[0])
+	}
+
+	invalid := []struct {
+		name     string
+		from, to Date
+		field    string
+	}{
+		{"nonexistent start", NewDate(2024, time.February, 30), NewDate(2024, time.March, 1), "date1"},
+		{"nonexistent end", NewDate(2024, time.March, 1), NewDate(2024, time.April, 31), "date2"},
+		{"reversed", NewDate(2024, time.March, 2), NewDate(2024, time.March, 1), "date2"},
+		{"longer than 366 days", NewDate(2023, time.January, 1), NewDate(2024, time.January, 2), "date2"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetByDates(tt.from, tt.to, Params{})
+			var validationErr *ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("GetByDates() error = %v, expected ValidationError for %s", err, tt.field)
+			}
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				t.Error("validation error must be distinct from APIError")
+			}
+		})
+	}
+
+	if _, err := client.GetByDates(NewDate(2023, time.January, 1), NewDate(2024, time.January, 1), Params{}); err != nil {
+		t.Errorf("GetByDates() for exactly 366 days failed: %v", err)
+	}
+	if got := api.requestCount(); got != 2 {
+		t.Errorf("server got %d requests, expected invalid periods to be rejected locally", got)
+	}
+}
+
+func TestGetByPeriodValidatesDates(t *testing.T) {
+	api, client := newFakeAPI(t)
+	for _, dates := range [][2]string{{"20240230", "20240301"}, {"20240301", "2024031"}, {"20240302", "20240301"}} {
+		_, err := client.GetByPeriod(dates[0], dates[1], Params{})
+		var validationErr *ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("GetByPeriod(%q, %q) error = %v, expected ValidationError", dates[0], dates[1], err)
+		}
+	}
+	if got := api.requestCount(); got != 0 {
+		t.Errorf("server got %d requests, expected 0", got)
+	}
+}
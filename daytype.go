@@ -0,0 +1,81 @@
+package isdayoff
+
+import "fmt"
+
+// dayTypeLabels holds English and Russian labels of known day types
+var dayTypeLabels = map[DayType][2]string{
+	DayTypeWorking:      {"working", "рабочий день"},
+	DayTypeNonWorking:   {"non-working", "нерабочий день"},
+	DayTypeHalfHoliday:  {"shortened", "сокращённый день"},
+	DayTypeWorkingCovid: {"working (covid)", "рабочий день (COVID)"},
+}
+
+// Valid reports whether the day type is one of the codes returned by the API
+func (d DayType) Valid() bool {
+	_, ok := dayTypeLabels[d]
+	return ok
+}
+
+// IsWorking reports whether people work on the day: shortened pre-holiday days
+// and days marked for COVID-19 are working days of the production calendar
+func (d DayType) IsWorking() bool {
+	return d == DayTypeWorking || d == DayTypeHalfHoliday || d == DayTypeWorkingCovid
+}
+
+// IsOff reports whether the day is a day off
+func (d DayType) IsOff() bool {
+	return d == DayTypeNonWorking
+}
+
+// IsShortened reports whether the day is a shortened pre-holiday working day
+func (d DayType) IsShortened() bool {
+	return d == DayTypeHalfHoliday
+}
+
+// String returns English label of the day type, e.g. "working"
+func (d DayType) String() string {
+	if labels, ok := dayTypeLabels[d]; ok {
+		return labels[0]
+	}
+	return fmt.Sprintf("unknown(%q)", string(d))
+}
+
+// StringRU returns Russian label of the day type, e.g. "рабочий день"
+func (d DayType) StringRU() string {
+	if labels, ok := dayTypeLabels[d]; ok {
+		return labels[1]
+	}
+	return fmt.Sprintf("неизвестный тип дня (%q)", string(d))
+}
+
+// MarshalText encodes the day type as its API code, e.g. "0"
+func (d DayType) MarshalText() ([]byte, error) {
+	if !d.Valid() {
+		return nil, &DayTypeError{Value: string(d), Index: -1}
+	}
+	return []byte(d), nil
+}
+
+// UnmarshalText decodes the day type from its API code or English label
+func (d *DayType) UnmarshalText(text []byte) error {
+	for day, labels := range dayTypeLabels {
+		if string(text) == string(day) || string(text) == labels[0] {
+			*d = day
+			return nil
+		}
+	}
+	return &DayTypeError{Value: string(text), Index: -1}
+}
+
+// DayTypeError reports a day type unknown to the library
+type DayTypeError struct {
+	Value string
+	Index int // позиция в ответе API, -1 если значение не из ответа
+}
+
+func (e *DayTypeError) Error() string {
+	if e.Index < 0 {
+		return fmt.Sprintf("unknown day type %q", e.Value)
+	}
+	return fmt.Sprintf("unknown day type %q at position %d of response", e.Value, e.Index)
+}
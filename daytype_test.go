@@ -0,0 +1,84 @@
+package isdayoff
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestDayTypeMethods(t *testing.T) {
+	tests := []struct {
+		day                            DayType
+		valid, working, off, shortened bool
+		en, ru                         string
+	}{
+		{DayTypeWorking, true, true, false, false, "working", "рабочий день"},
+		{DayTypeNonWorking, true, false, true, false, "non-working", "нерабочий день"},
+		{DayTypeHalfHoliday, true, true, false, true, "shortened", "сокращённый день"},
+		{DayTypeWorkingCovid, true, true, false, false, "working (covid)", "рабочий день (COVID)"},
+		{DayType("9"), false, false, false, false, `unknown("9")`, `неизвестный тип дня ("9")`},
+		{DayType(""), false, false, false, false, `unknown("")`, `неизвестный тип дня ("")`},
+	}
+	for _, tt := range tests {
+		if tt.day.Valid() != tt.valid || tt.day.IsWorking() != tt.working || tt.day.IsOff() != tt.off || tt.day.IsShortened() != tt.shortened {
+			t.Errorf("DayType(%q): Valid=%v IsWorking=%v IsOff=%v IsShortened=%v", string(tt.day),
+				tt.day.Valid(), tt.day.IsWorking(), tt.day.IsOff(), tt.day.IsShortened())
+		}
+		if tt.day.String() != tt.en || tt.day.StringRU() != tt.ru {
+			t.Errorf("DayType(%q) labels = %q, %q; expected %q, %q", string(tt.day), tt.day.String(), tt.day.StringRU(), tt.en, tt.ru)
+		}
+	}
+}
+
+func TestDayTypeJSON(t *testing.T) {
+	type record struct {
+		Day  DayType   `json:"day"`
+		Days []DayType `json:"days"`
+	}
+	data, err := json.Marshal(record{Day: DayTypeHalfHoliday, Days: []DayType{DayTypeWorking, DayTypeNonWorking}})
+	if err != nil {
+		t.Fatalf("json.Marshal() failed: %v", err)
+	}
+	if string(data) != `{"day":"2","days":["0","1"]}` {
+		t.Errorf("json.Marshal() = %s", data)
+	}
+
+	var decoded record
+	if err := json.Unmarshal([]byte(`{"day":"shortened","days":["0","non-working"]}`), &decoded); err != nil {
+		t.Fatalf("json.Unmarshal() failed: %v", err)
+	}
+	if decoded.Day != DayTypeHalfHoliday || len(decoded.Days) != 2 || decoded.Days[1] != DayTypeNonWorking {
+		t.Errorf("json.Unmarshal() = %+v", decoded)
+	}
+
+	var typeErr *DayTypeError
+	if err := json.Unmarshal([]byte(`{"day":"7"}`), &decoded); !errors.As(err, &typeErr) {
+		t.Errorf("json.Unmarshal() of unknown day type error = %v, expected DayTypeError", err)
+	}
+	if _, err := json.Marshal(record{Day: "x"}); !errors.As(err, &typeErr) {
+		t.Errorf("json.Marshal() of unknown day type error = %v, expected DayTypeError", err)
+	}
+}
+
+func TestUnknownDayTypeInResponse(t *testing.T) {
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/today" {
+			w.Write([]byte("5"))
+			return
+		}
+		w.Write([]byte("0011x0"))
+	}))
+
+	var typeErr *DayTypeError
+	_, err := client.GetByPeriod("20240101", "20240106", Params{})
+	if !errors.As(err, &typeErr) || typeErr.Value != "x" || typeErr.Index != 4 {
+		t.Errorf("GetByPeriod() error = %v, expected DayTypeError for \"x\" at 4", err)
+	}
+	if _, err := client.GetBy(Params{Year: 2024}); !errors.As(err, &typeErr) {
+		t.Errorf("GetBy() error = %v, expected DayTypeError", err)
+	}
+	if _, err := client.Today(Params{}); !errors.As(err, &typeErr) || typeErr.Value != "5" {
+		t.Errorf("Today() error = %v, expected DayTypeError for \"5\"", err)
+	}
+}
@@ -0,0 +1,122 @@
+package isdayoff
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeAPI эмулирует isdayoff.ru: выходными считаются суббота и воскресенье
+// (только воскресенье при sd=1), а дни из holidays — нерабочими
+type fakeAPI struct {
+	mu       sync.Mutex
+	requests []string
+	holidays map[string]bool // даты в формате YYYYMMDD
+	codes    map[string]byte // коды отдельных дат, например '2' для сокращённых дней
+	now      time.Time       // дата, которую сервер считает сегодняшней
+}
+
+func newFakeAPI(t *testing.T, opts ...Option) (*fakeAPI, *Client) {
+	t.Helper()
+	api := &fakeAPI{holidays: map[string]bool{}, codes: map[string]byte{}, now: time.Date(2024, time.December, 31, 10, 0, 0, 0, time.UTC)}
+	return api, newTestClient(t, api, opts...)
+}
+
+func (a *fakeAPI) requestCount() int {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return len(a.requests)
+}
+
+func (a *fakeAPI) day(date time.Time, q map[string]string) byte {
+	if code, ok := a.codes[date.Format("20060102")]; ok {
+		return code
+	}
+	if a.holidays[date.Format("20060102")] {
+		return '1'
+	}
+	if date.Weekday() == time.Sunday || (date.Weekday() == time.Saturday && q["sd"] != "1") {
+		return '1'
+	}
+	return '0'
+}
+
+func (a *fakeAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.Lock()
+	a.requests = append(a.requests, r.URL.Path+"?"+r.URL.RawQuery)
+	now := a.now
+	a.mu.Unlock()
+
+	q := map[string]string{}
+	for k, v := range r.URL.Query() {
+		q[k] = v[0]
+	}
+
+	var from, to time.Time
+	switch r.URL.Path {
+	case "/api/isleap":
+		year, _ := strconv.Atoi(q["year"])
+		w.Write([]byte(strconv.Itoa(map[bool]int{false: 0, true: 1}[daysIn(year) == 366])))
+		return
+	case "/today":
+		from, to = now, now
+	case "/tomorrow":
+		from = now.AddDate(0, 0, 1)
+		to = from
+	case "/api/getdata":
+		if q["date1"] != "" {
+			var err1, err2 error
+			from, err1 = time.Parse("20060102", q["date1"])
+			to, err2 = time.Parse("20060102", q["date2"])
+			if err1 != nil || err2 != nil || to.Before(from) || to.Sub(from) >= 366*24*time.Hour {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("100"))
+				return
+			}
+			break
+		}
+		year, err := strconv.Atoi(q["year"])
+		if err != nil || year < 1 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("100"))
+			return
+		}
+		from = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(1, 0, -1)
+		if m, err := strconv.Atoi(q["month"]); err == nil {
+			from = time.Date(year, time.Month(m), 1, 0, 0, 0, 0, time.UTC)
+			to = from.AddDate(0, 1, -1)
+			d, err := strconv.Atoi(q["day"])
+			if m < 1 || m > 12 || (err == nil && (d < 1 || d > to.Day())) {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte("100"))
+				return
+			}
+			if err == nil {
+				from = from.AddDate(0, 0, d-1)
+				to = from
+			}
+		}
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("101"))
+		return
+	}
+
+	var b strings.Builder
+	for d := from; !d.After(to); d = d.AddDate(0, 0, 1) {
+		b.WriteByte(a.day(d, q))
+	}
+	w.Write([]byte(b.String()))
+}
+
+// http404 отвечает ошибкой «данные не найдены» на любой запрос
+func http404() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("101"))
+	})
+}
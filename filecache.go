@@ -0,0 +1,182 @@
+package isdayoff
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrStale is reported along with data served from cache because the API is unreachable
+var ErrStale = errors.New("stale data")
+
+// StaleError is returned together with outdated data when the API could not be reached.
+// errors.Is(err, ErrStale) reports whether the returned data can still be used.
+type StaleError struct {
+	FetchedAt time.Time // когда данные были получены из API
+	Err       error     // ошибка обращения к API
+}
+
+func (e *StaleError) Error() string {
+	return fmt.Sprintf("serving stale data fetched at %s: %v", e.FetchedAt.Format(time.RFC3339), e.Err)
+}
+
+func (e *StaleError) Unwrap() []error {
+	return []error{ErrStale, e.Err}
+}
+
+// FileCache stores year calendars on disk, one file per country, year and flags.
+// Files are written atomically and verified with a checksum when read.
+// Entries older than TTL are not served while the API is available,
+// but are kept to be served as stale data during outages: in that case
+// client methods return the data together with StaleError.
+type FileCache struct {
+	dir   string
+	ttl   time.Duration
+	clock Clock
+}
+
+// fileEntry is the file format of FileCache
+type fileEntry struct {
+	Year        int         `json:"year"`
+	CountryCode CountryCode `json:"cc"`
+	Pre         bool        `json:"pre"`
+	Covid       bool        `json:"covid"`
+	SixDayWeek  bool        `json:"sd"`
+	FetchedAt   time.Time   `json:"fetched_at"`
+	Days        string      `json:"days"`
+	Checksum    string      `json:"sha256"`
+}
+
+// NewFileCache creates cache in dir, creating the directory if needed.
+// Zero ttl means entries never become outdated.
+func NewFileCache(dir string, ttl time.Duration, opts ...CacheOption) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("os.MkdirAll failed: %w", err)
+	}
+	return &FileCache{dir: dir, ttl: ttl, clock: newCacheOptions(opts).clock}, nil
+}
+
+func (f *FileCache) path(key YearKey) string {
+	name := fmt.Sprintf("%s-%04d-pre%s-covid%s-sd%s.json",
+		key.CountryCode, key.Year, boolToStr[key.Pre], boolToStr[key.Covid], boolToStr[key.SixDayWeek])
+	return filepath.Join(f.dir, name)
+}
+
+// Get returns the year calendar if it is present and not older than TTL
+func (f *FileCache) Get(ctx context.Context, key YearKey) ([]DayType, bool, error) {
+	days, fetchedAt, ok, err := f.GetStale(ctx, key)
+	if !ok || err != nil {
+		return nil, false, err
+	}
+	if f.ttl > 0 && !f.clock.Now().Before(fetchedAt.Add(f.ttl)) {
+		return nil, false, nil
+	}
+	return days, true, nil
+}
+
+// GetStale returns the year calendar regardless of its age along with the time it was fetched.
+// Corrupted files are removed and reported as missing.
+func (f *FileCache) GetStale(_ context.Context, key YearKey) ([]DayType, time.Time, bool, error) {
+	data, err := os.ReadFile(f.path(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, time.Time{}, false, nil
+	}
+	if err != nil {
+		return nil, time.Time{}, false, fmt.Errorf("os.ReadFile failed: %w", err)
+	}
+
+	var entry fileEntry
+	if err := json.Unmarshal(data, &entry); err != nil || !entry.valid(key) {
+		os.Remove(f.path(key))
+		return nil, time.Time{}, false, nil
+	}
+	days, err := parseDays([]byte(entry.Days))
+	if err != nil {
+		os.Remove(f.path(key))
+		return nil, time.Time{}, false, nil
+	}
+	return days, entry.FetchedAt, true, nil
+}
+
+// valid verifies that the entry is intact and belongs to key
+func (e *fileEntry) valid(key YearKey) bool {
+	return e.Year == key.Year && e.CountryCode == key.CountryCode &&
+		e.Pre == key.Pre && e.Covid == key.Covid && e.SixDayWeek == key.SixDayWeek &&
+		len(e.Days) == daysIn(key.Year) && e.Checksum == checksum(e.Days)
+}
+
+func checksum(days string) string {
+	sum := sha256.Sum256([]byte(days))
+	return hex.EncodeToString(sum[:])
+}
+
+// Set writes the year calendar to a temporary file and atomically renames it
+func (f *FileCache) Set(_ context.Context, key YearKey, days []DayType) error {
+	var b strings.Builder
+	for _, day := range days {
+		b.WriteString(string(day))
+	}
+	data, err := json.Marshal(fileEntry{
+		Year:        key.Year,
+		CountryCode: key.CountryCode,
+		Pre:         key.Pre,
+		Covid:       key.Covid,
+		SixDayWeek:  key.SixDayWeek,
+		FetchedAt:   f.clock.Now().UTC(),
+		Days:        b.String(),
+		Checksum:    checksum(b.String()),
+	})
+	if err != nil {
+		return fmt.Errorf("json.Marshal failed: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(f.dir, ".tmp-*")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp failed: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("file.Write failed: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("file.Sync failed: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("file.Close failed: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), f.path(key)); err != nil {
+		return fmt.Errorf("os.Rename failed: %w", err)
+	}
+	return nil
+}
+
+// Delete removes the year calendar file
+func (f *FileCache) Delete(_ context.Context, key YearKey) error {
+	if err := os.Remove(f.path(key)); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.Remove failed: %w", err)
+	}
+	return nil
+}
+
+// unreachable reports whether err means the API could not give an answer,
+// as opposed to rejecting the request or the caller giving up
+func unreachable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == ErrorCodeInternalError
+	}
+	return true
+}
@@ -0,0 +1,165 @@
+package isdayoff
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestFileCacheRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	dir := filepath.Join(t.TempDir(), "calendars")
+	clock := newFakeClock(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC))
+	cache, err := NewFileCache(dir, time.Hour, WithCacheClock(clock))
+	if err != nil {
+		t.Fatalf("NewFileCache() failed: %v", err)
+	}
+
+	key := YearKey{Year: 2023, CountryCode: CountryCodeBelarus, Pre: true}
+	days := slices.Repeat([]DayType{DayTypeWorking, DayTypeHalfHoliday, DayTypeNonWorking}, 365/5)
+	days = append(days, slices.Repeat([]DayType{DayTypeWorking}, 365-len(days))...)
+
+	if _, ok, err := cache.Get(ctx, key); ok || err != nil {
+		t.Fatalf("Get() on empty cache = %v, %v", ok, err)
+	}
+	if err := cache.Set(ctx, key, days); err != nil {
+		t.Fatalf("Set() failed: %v", err)
+	}
+
+	entries, _ := os.ReadDir(dir)
+	if len(entries) != 1 || entries[0].Name() != "by-2023-pre1-covid0-sd0.json" {
+		t.Errorf("cache dir contains %v, expected a single calendar file without temporary files", entries)
+	}
+
+	got, ok, err := cache.Get(ctx, key)
+	if !ok || err != nil || !slices.Equal(got, days) {
+		t.Fatalf("Get() = %v, %v; expected stored days", ok, err)
+	}
+	if _, ok, _ := cache.Get(ctx, YearKey{Year: 2023, CountryCode: CountryCodeBelarus}); ok {
+		t.Error("Get() with other flags hit the entry")
+	}
+
+	clock.Advance(time.Hour)
+	if _, ok, _ := cache.Get(ctx, key); ok {
+		t.Error("Get() returned entry older than TTL")
+	}
+	stale, fetchedAt, ok, err := cache.GetStale(ctx, key)
+	if !ok || err != nil || !slices.Equal(stale, days) {
+		t.Fatalf("GetStale() = %v, %v", ok, err)
+	}
+	if !fetchedAt.Equal(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("GetStale() fetchedAt = %v", fetchedAt)
+	}
+
+	if err := cache.Delete(ctx, key); err != nil {
+		t.Fatalf("Delete() failed: %v", err)
+	}
+	if _, _, ok, _ := cache.GetStale(ctx, key); ok {
+		t.Error("GetStale() returned deleted entry")
+	}
+}
+
+func TestFileCacheIntegrity(t *testing.T) {
+	ctx := context.Background()
+	cache, err := NewFileCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatalf("NewFileCache() failed: %v", err)
+	}
+	key := YearKey{Year: 2021, CountryCode: CountryCodeRussia}
+	cache.Set(ctx, key, slices.Repeat([]DayType{DayTypeWorking}, 365))
+
+	// меняем один день, не обновляя контрольную сумму
+	path := cache.path(key)
+	data, _ := os.ReadFile(path)
+	var entry fileEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		t.Fatalf("json.Unmarshal failed: %v", err)
+	}
+	entry.Days = "1" + entry.Days[1:]
+	data, _ = json.Marshal(entry)
+	os.WriteFile(path, data, 0o644)
+
+	if _, ok, err := cache.Get(ctx, key); ok || err != nil {
+		t.Errorf("Get() on corrupted file = %v, %v; expected miss", ok, err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Error("corrupted file was not removed")
+	}
+
+	os.WriteFile(path, []byte("{not json"), 0o644)
+	if _, ok, _ := cache.Get(ctx, key); ok {
+		t.Error("Get() on malformed file reported hit")
+	}
+}
+
+func TestFileCacheSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	cache, _ := NewFileCache(dir, 0)
+	api, client := newFakeAPI(t, WithCache(cache))
+	if _, err := client.GetBy(Params{Year: 2024}); err != nil {
+		t.Fatalf("GetBy() failed: %v", err)
+	}
+
+	restarted, _ := NewFileCache(dir, 0)
+	client = newTestClient(t, api, WithCache(restarted))
+	month := time.May
+	days, err := client.GetBy(Params{Year: 2024, Month: &month})
+	if err != nil || len(days) != 31 {
+		t.Fatalf("GetBy() after restart = %d days, %v", len(days), err)
+	}
+	if got := api.requestCount(); got != 1 {
+		t.Errorf("server got %d requests, expected the restarted client to use files: %v", got, api.requests)
+	}
+}
+
+func TestFileCacheStaleFallback(t *testing.T) {
+	dir := t.TempDir()
+	clock := newFakeClock(time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC))
+	cache, _ := NewFileCache(dir, time.Hour, WithCacheClock(clock))
+	_, client := newFakeAPI(t, WithCache(cache), WithClock(clock))
+	if _, err := client.GetBy(Params{Year: 2024}); err != nil {
+		t.Fatalf("GetBy() failed: %v", err)
+	}
+	clock.Advance(2 * time.Hour)
+
+	status := http.StatusInternalServerError
+	body := "199"
+	down := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}), WithCache(cache), WithClock(clock))
+
+	utc := "UTC"
+	day, err := down.Today(Params{TZ: &utc})
+	if !errors.Is(err, ErrStale) {
+		t.Fatalf("Today() error = %v, expected ErrStale", err)
+	}
+	var staleErr *StaleError
+	if !errors.As(err, &staleErr) || !staleErr.FetchedAt.Equal(time.Date(2024, time.March, 4, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Today() error = %#v, expected StaleError with fetch time", err)
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeInternalError {
+		t.Errorf("StaleError does not wrap API error: %v", err)
+	}
+	if day == nil || *day != DayTypeWorking {
+		t.Errorf("Today() = %v, expected stale working Monday", day)
+	}
+
+	days, err := down.GetByPeriod("20240301", "20240310", Params{})
+	if !errors.Is(err, ErrStale) || len(days) != 10 {
+		t.Errorf("GetByPeriod() = %d days, %v; expected stale data", len(days), err)
+	}
+
+	// ошибка в запросе не считается недоступностью API
+	status, body = http.StatusNotFound, "101"
+	if _, err := down.GetBy(Params{Year: 2024}); errors.Is(err, ErrStale) || err == nil {
+		t.Errorf("GetBy() error = %v, expected API error without stale data", err)
+	}
+}
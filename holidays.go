@@ -0,0 +1,251 @@
+package isdayoff
+
+import (
+	"slices"
+	"time"
+)
+
+// Holiday is a public holiday from the built-in catalogue
+type Holiday struct {
+	Date   time.Time // полночь UTC
+	NameRU string
+	NameEN string
+}
+
+// holidayRule describes a holiday of the catalogue. Fixed holidays have month and day,
+// movable feasts compute the first day of the year with date.
+type holidayRule struct {
+	month  time.Month
+	day    int
+	date   func(year int) (time.Time, bool) // подвижный праздник, nil для фиксированной даты
+	length int                              // число дней праздника, 0 — один день
+	from   int                              // первый год действия, 0 — без ограничения
+	to     int                              // последний год действия, 0 — без ограничения
+	nameRU string
+	nameEN string
+}
+
+// fixed returns a rule for a holiday with a fixed date
+func fixed(month time.Month, day int, nameRU, nameEN string) holidayRule {
+	return holidayRule{month: month, day: day, nameRU: nameRU, nameEN: nameEN}
+}
+
+// movable returns a rule for a holiday with a date computed for every year
+func movable(date func(year int) (time.Time, bool), nameRU, nameEN string) holidayRule {
+	return holidayRule{date: date, nameRU: nameRU, nameEN: nameEN}
+}
+
+// years limits the rule to years from..to inclusive, 0 means no bound
+func (r holidayRule) years(from, to int) holidayRule {
+	r.from, r.to = from, to
+	return r
+}
+
+// days makes the holiday last n days
+func (r holidayRule) days(n int) holidayRule {
+	r.length = n
+	return r
+}
+
+// holidays returns days of the holiday in the year
+func (r holidayRule) holidays(year int) []Holiday {
+	if (r.from != 0 && year < r.from) || (r.to != 0 && year > r.to) {
+		return nil
+	}
+	first := time.Date(year, r.month, r.day, 0, 0, 0, 0, time.UTC)
+	if r.date != nil {
+		var ok bool
+		if first, ok = r.date(year); !ok {
+			return nil
+		}
+	}
+	result := make([]Holiday, 0, max(r.length, 1))
+	for i := range max(r.length, 1) {
+		result = append(result, Holiday{Date: first.AddDate(0, 0, i), NameRU: r.nameRU, NameEN: r.nameEN})
+	}
+	return result
+}
+
+// Holidays returns public holidays of the country in the year from the built-in catalogue,
+// ordered by date. The catalogue lists holidays set by law; days off moved by transfers
+// and holidays observed on another day are not included. Feasts of the lunar calendar
+// are known only for the years listed in the catalogue.
+func Holidays(cc CountryCode, year int) []Holiday {
+	var result []Holiday
+	for _, rule := range holidayCatalogue[cc] {
+		result = append(result, rule.holidays(year)...)
+	}
+	slices.SortStableFunc(result, func(a, b Holiday) int {
+		return a.Date.Compare(b.Date)
+	})
+	return result
+}
+
+// HolidayOn returns the public holiday of the country on the date of t
+func HolidayOn(cc CountryCode, t time.Time) (Holiday, bool) {
+	date := dateOf(t)
+	for _, h := range Holidays(cc, date.Year()) {
+		if h.Date.Equal(date) {
+			return h, true
+		}
+	}
+	return Holiday{}, false
+}
+
+// orthodoxEaster returns the date of Orthodox Easter in the Gregorian calendar
+func orthodoxEaster(year int) (time.Time, bool) {
+	a, b, c := year%4, year%7, year%19
+	d := (19*c + 15) % 30
+	e := (2*a + 4*b - d + 34) % 7
+	month := time.Month((d + e + 114) / 31)
+	day := (d+e+114)%31 + 1
+	// разница между юлианским и григорианским календарями
+	shift := year/100 - year/400 - 2
+	return time.Date(year, month, day+shift, 0, 0, 0, 0, time.UTC), true
+}
+
+// afterEaster returns date of the feast n days after Orthodox Easter
+func afterEaster(n int) func(year int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		easter, ok := orthodoxEaster(year)
+		return easter.AddDate(0, 0, n), ok
+	}
+}
+
+// weekdayOf returns date of the n-th weekday of the month, the last one if n is -1
+func weekdayOf(month time.Month, weekday time.Weekday, n int) func(year int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		if n < 0 {
+			last := time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC)
+			return last.AddDate(0, 0, -(int(last.Weekday()-weekday+7) % 7)), true
+		}
+		first := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+		return first.AddDate(0, 0, int(weekday-first.Weekday()+7)%7+7*(n-1)), true
+	}
+}
+
+// lunar returns date of a feast of the lunar calendar from the table
+func lunar(table map[int]string) func(year int) (time.Time, bool) {
+	return func(year int) (time.Time, bool) {
+		s, ok := table[year]
+		if !ok {
+			return time.Time{}, false
+		}
+		t, err := time.Parse(time.DateOnly, s)
+		return t, err == nil
+	}
+}
+
+// Первые дни Ураза-байрама и Курбан-байрама в Турции, Узбекистане и Казахстане.
+// Даты с 2027 года рассчитаны по новолунию: месяц начинается на следующий день, если
+// соединение произошло до 15:00 UTC, иначе через день (правило совпадает с датами
+// 2020–2026 годов). Официально объявленные даты могут отличаться на день. В 2033 году
+// Ураза-байрам приходится на год дважды, поэтому таблицы заканчиваются 2032 годом.
+var (
+	eidAlFitr = map[int]string{
+		2020: "2020-05-24", 2021: "2021-05-13", 2022: "2022-05-02", 2023: "2023-04-21",
+		2024: "2024-04-10", 2025: "2025-03-30", 2026: "2026-03-20", 2027: "2027-03-09",
+		2028: "2028-02-26", 2029: "2029-02-14", 2030: "2030-02-04", 2031: "2031-01-24",
+		2032: "2032-01-14",
+	}
+	eidAlAdha = map[int]string{
+		2020: "2020-07-31", 2021: "2021-07-20", 2022: "2022-07-09", 2023: "2023-06-28",
+		2024: "2024-06-16", 2025: "2025-06-06", 2026: "2026-05-27", 2027: "2027-05-16",
+		2028: "2028-05-05", 2029: "2029-04-24", 2030: "2030-04-13", 2031: "2031-04-02",
+		2032: "2032-03-22",
+	}
+)
+
+// holidayCatalogue lists public holidays of the supported countries
+var holidayCatalogue = map[CountryCode][]holidayRule{
+	CountryCodeRussia: {
+		fixed(time.January, 1, "Новогодние каникулы", "New Year holidays").days(5),
+		fixed(time.January, 6, "Новогодние каникулы", "New Year holidays").years(2013, 0),
+		fixed(time.January, 7, "Рождество Христово", "Orthodox Christmas"),
+		fixed(time.January, 8, "Новогодние каникулы", "New Year holidays").years(2013, 0),
+		fixed(time.February, 23, "День защитника Отечества", "Defender of the Fatherland Day"),
+		fixed(time.March, 8, "Международный женский день", "International Women's Day"),
+		fixed(time.May, 1, "Праздник Весны и Труда", "Spring and Labour Day"),
+		fixed(time.May, 9, "День Победы", "Victory Day"),
+		fixed(time.June, 12, "День России", "Russia Day"),
+		fixed(time.November, 4, "День народного единства", "Unity Day").years(2005, 0),
+	},
+	CountryCodeBelarus: {
+		fixed(time.January, 1, "Новый год", "New Year's Day"),
+		fixed(time.January, 2, "Новый год", "New Year's Day").years(2020, 0),
+		fixed(time.January, 7, "Рождество Христово (православное)", "Orthodox Christmas"),
+		fixed(time.March, 8, "День женщин", "Women's Day"),
+		movable(afterEaster(9), "Радуница", "Radunitsa"),
+		fixed(time.May, 1, "Праздник труда", "Labour Day"),
+		fixed(time.May, 9, "День Победы", "Victory Day"),
+		fixed(time.July, 3, "День Независимости", "Independence Day"),
+		fixed(time.November, 7, "День Октябрьской революции", "October Revolution Day"),
+		fixed(time.December, 25, "Рождество Христово (католическое)", "Catholic Christmas"),
+	},
+	CountryCodeKazakhstan: {
+		fixed(time.January, 1, "Новый год", "New Year's Day").days(2),
+		fixed(time.January, 7, "Рождество Христово", "Orthodox Christmas"),
+		fixed(time.March, 8, "Международный женский день", "International Women's Day"),
+		fixed(time.March, 21, "Наурыз мейрамы", "Nauryz").days(3),
+		fixed(time.May, 1, "Праздник единства народа Казахстана", "Kazakhstan People's Unity Day"),
+		fixed(time.May, 7, "День защитника Отечества", "Defender of the Fatherland Day").years(2013, 0),
+		fixed(time.May, 9, "День Победы", "Victory Day"),
+		movable(lunar(eidAlAdha), "Курбан айт", "Kurban Ait").years(2017, 0),
+		fixed(time.July, 6, "День столицы", "Capital Day"),
+		fixed(time.August, 30, "День Конституции", "Constitution Day"),
+		fixed(time.October, 25, "День Республики", "Republic Day").years(2022, 0),
+		fixed(time.December, 16, "День Независимости", "Independence Day"),
+		fixed(time.December, 17, "День Независимости", "Independence Day").years(0, 2021),
+	},
+	CountryCodeUkraine: {
+		fixed(time.January, 1, "Новый год", "New Year's Day"),
+		fixed(time.January, 7, "Рождество Христово", "Orthodox Christmas").years(0, 2023),
+		fixed(time.March, 8, "Международный женский день", "International Women's Day"),
+		movable(afterEaster(0), "Пасха", "Easter"),
+		movable(afterEaster(49), "Троица", "Trinity Sunday"),
+		fixed(time.May, 1, "День труда", "Labour Day"),
+		fixed(time.May, 2, "День труда", "Labour Day").years(0, 2017),
+		fixed(time.May, 8, "День памяти и победы над нацизмом", "Day of Remembrance and Victory over Nazism").years(2023, 0),
+		fixed(time.May, 9, "День победы над нацизмом", "Victory Day over Nazism").years(0, 2022),
+		fixed(time.June, 28, "День Конституции", "Constitution Day"),
+		fixed(time.August, 24, "День Независимости", "Independence Day"),
+		fixed(time.October, 1, "День защитников и защитниц", "Defenders Day").years(2023, 0),
+		fixed(time.October, 14, "День защитника Украины", "Defender of Ukraine Day").years(2015, 2022),
+		fixed(time.December, 25, "Рождество Христово", "Christmas").years(2017, 0),
+	},
+	CountryCodeUSA: {
+		fixed(time.January, 1, "Новый год", "New Year's Day"),
+		movable(weekdayOf(time.January, time.Monday, 3), "День Мартина Лютера Кинга", "Martin Luther King Jr. Day"),
+		movable(weekdayOf(time.February, time.Monday, 3), "День рождения Вашингтона", "Washington's Birthday"),
+		movable(weekdayOf(time.May, time.Monday, -1), "День памяти", "Memorial Day"),
+		fixed(time.June, 19, "Джунтинс", "Juneteenth").years(2021, 0),
+		fixed(time.July, 4, "День независимости", "Independence Day"),
+		movable(weekdayOf(time.September, time.Monday, 1), "День труда", "Labor Day"),
+		movable(weekdayOf(time.October, time.Monday, 2), "День Колумба", "Columbus Day"),
+		fixed(time.November, 11, "День ветеранов", "Veterans Day"),
+		movable(weekdayOf(time.November, time.Thursday, 4), "День благодарения", "Thanksgiving Day"),
+		fixed(time.December, 25, "Рождество", "Christmas Day"),
+	},
+	CountryCodeUzbekistan: {
+		fixed(time.January, 1, "Новый год", "New Year's Day"),
+		fixed(time.March, 8, "Международный женский день", "International Women's Day"),
+		fixed(time.March, 21, "Навруз", "Navruz"),
+		movable(lunar(eidAlFitr), "Рамазан хайит", "Ramadan Hayit"),
+		fixed(time.May, 9, "День памяти и почестей", "Day of Remembrance and Honour"),
+		movable(lunar(eidAlAdha), "Курбан хайит", "Qurbon Hayit"),
+		fixed(time.September, 1, "День Независимости", "Independence Day"),
+		fixed(time.October, 1, "День учителя и наставника", "Teachers' Day"),
+		fixed(time.December, 8, "День Конституции", "Constitution Day"),
+	},
+	CountryCodeTurkey: {
+		fixed(time.January, 1, "Новый год", "New Year's Day"),
+		movable(lunar(eidAlFitr), "Рамазан-байрам", "Ramadan Feast").days(3),
+		fixed(time.April, 23, "День национального суверенитета и детей", "National Sovereignty and Children's Day"),
+		fixed(time.May, 1, "День труда и солидарности", "Labour and Solidarity Day").years(2009, 0),
+		fixed(time.May, 19, "День памяти Ататюрка, молодёжи и спорта", "Commemoration of Atatürk, Youth and Sports Day"),
+		movable(lunar(eidAlAdha), "Курбан-байрам", "Sacrifice Feast").days(4),
+		fixed(time.July, 15, "День демократии и национального единства", "Democracy and National Unity Day").years(2017, 0),
+		fixed(time.August, 30, "День Победы", "Victory Day"),
+		fixed(time.October, 29, "День Республики", "Republic Day"),
+	},
+}
@@ -0,0 +1,97 @@
+package isdayoff
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestHolidayCatalogue(t *testing.T) {
+	for _, cc := range []CountryCode{CountryCodeBelarus, CountryCodeKazakhstan, CountryCodeRussia, CountryCodeUkraine, CountryCodeUSA, CountryCodeUzbekistan, CountryCodeTurkey} {
+		holidays := Holidays(cc, 2024)
+		if len(holidays) == 0 {
+			t.Errorf("Holidays(%s, 2024) is empty", cc)
+		}
+		if !slices.IsSortedFunc(holidays, func(a, b Holiday) int { return a.Date.Compare(b.Date) }) {
+			t.Errorf("Holidays(%s, 2024) is not ordered by date", cc)
+		}
+		for _, h := range holidays {
+			if h.NameRU == "" || h.NameEN == "" || h.Date.Year() != 2024 {
+				t.Errorf("Holidays(%s, 2024) has incomplete entry %+v", cc, h)
+			}
+		}
+	}
+	if got := Holidays(CountryCode("xx"), 2024); got != nil {
+		t.Errorf("Holidays() for unknown country = %v", got)
+	}
+}
+
+func TestHolidayOn(t *testing.T) {
+	tests := []struct {
+		cc     CountryCode
+		date   time.Time
+		nameEN string // пусто, если праздника нет
+	}{
+		{CountryCodeRussia, date(2024, time.May, 9), "Victory Day"},
+		{CountryCodeRussia, date(2024, time.January, 8), "New Year holidays"},
+		{CountryCodeRussia, date(2012, time.January, 8), ""},
+		{CountryCodeRussia, date(2024, time.May, 10), ""},
+		{CountryCodeBelarus, date(2024, time.May, 14), "Radunitsa"},
+		{CountryCodeBelarus, date(2025, time.April, 29), "Radunitsa"},
+		{CountryCodeUkraine, date(2023, time.April, 16), "Easter"},
+		{CountryCodeUkraine, date(2024, time.June, 23), "Trinity Sunday"},
+		{CountryCodeUkraine, date(2024, time.October, 14), ""},
+		{CountryCodeUSA, date(2024, time.January, 15), "Martin Luther King Jr. Day"},
+		{CountryCodeUSA, date(2024, time.May, 27), "Memorial Day"},
+		{CountryCodeUSA, date(2024, time.September, 2), "Labor Day"},
+		{CountryCodeUSA, date(2024, time.November, 28), "Thanksgiving Day"},
+		{CountryCodeUSA, date(2020, time.June, 19), ""},
+		{CountryCodeKazakhstan, date(2021, time.December, 17), "Independence Day"},
+		{CountryCodeKazakhstan, date(2022, time.December, 17), ""},
+		{CountryCodeKazakhstan, date(2024, time.June, 16), "Kurban Ait"},
+		{CountryCodeTurkey, date(2024, time.April, 12), "Ramadan Feast"},
+		{CountryCodeTurkey, date(2024, time.April, 13), ""},
+		{CountryCodeTurkey, date(2024, time.June, 19), "Sacrifice Feast"},
+		{CountryCodeTurkey, date(2030, time.February, 4), "Ramadan Feast"},
+		{CountryCodeUzbekistan, date(2032, time.March, 22), "Qurbon Hayit"},
+		{CountryCodeUzbekistan, date(2033, time.January, 2), ""}, // таблицы заканчиваются 2032 годом
+		{CountryCodeUzbekistan, date(2019, time.June, 5), ""},    // дат лунного календаря за этот год нет в каталоге
+	}
+	for _, tt := range tests {
+		h, ok := HolidayOn(tt.cc, tt.date)
+		if ok != (tt.nameEN != "") || h.NameEN != tt.nameEN {
+			t.Errorf("HolidayOn(%s, %s) = %q, %v; expected %q", tt.cc, tt.date.Format(time.DateOnly), h.NameEN, ok, tt.nameEN)
+		}
+	}
+}
+
+func TestOrthodoxEaster(t *testing.T) {
+	for year, expected := range map[int]time.Time{
+		2000: date(2000, time.April, 30),
+		2021: date(2021, time.May, 2),
+		2023: date(2023, time.April, 16),
+		2024: date(2024, time.May, 5),
+		2025: date(2025, time.April, 20),
+		2026: date(2026, time.April, 12),
+	} {
+		if got, _ := orthodoxEaster(year); !got.Equal(expected) {
+			t.Errorf("orthodoxEaster(%d) = %s, expected %s", year, got.Format(time.DateOnly), expected.Format(time.DateOnly))
+		}
+	}
+}
+
+// Праздники из каталога РФ должны быть нерабочими днями в производственных календарях
+func TestRussianHolidaysAreOff(t *testing.T) {
+	for _, year := range []int{2023, 2024} {
+		data, err := embeddedData.ReadFile(fmt.Sprintf("data/ru-%d.txt", year))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, h := range Holidays(CountryCodeRussia, year) {
+			if day := DayType(data[h.Date.YearDay()-1]); !day.IsOff() {
+				t.Errorf("%s (%s) is %v in production calendar", h.NameRU, h.Date.Format(time.DateOnly), day)
+			}
+		}
+	}
+}
@@ -0,0 +1,192 @@
+// Package ical exports production calendars in iCalendar format (RFC 5545),
+// so that days off can be subscribed to in Outlook, Google Calendar and others.
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+// Option configures the export
+type Option func(*encoder)
+
+// WithName sets the name of the calendar shown by calendar applications
+func WithName(name string) Option {
+	return func(e *encoder) {
+		e.name = name
+	}
+}
+
+// WithStamp sets DTSTAMP of events, the current time by default.
+// A fixed stamp keeps the output identical between exports of the same data.
+func WithStamp(stamp time.Time) Option {
+	return func(e *encoder) {
+		e.stamp = stamp
+	}
+}
+
+// WithWeekends exports ordinary weekends too. By default a run of days off
+// is exported only if it contains a holiday or a day off by transfer.
+func WithWeekends() Option {
+	return func(e *encoder) {
+		e.weekends = true
+	}
+}
+
+// WithPrevious passes days immediately preceding start, e.g. the end of the previous year.
+// An event already running at start then begins on its real first day, so its UID does
+// not depend on where the export begins. Without them such an event starts at start.
+func WithPrevious(days []isdayoff.DayType) Option {
+	return func(e *encoder) {
+		e.previous = days
+	}
+}
+
+type encoder struct {
+	name     string
+	stamp    time.Time
+	weekends bool
+	previous []isdayoff.DayType
+}
+
+// event is a run of consecutive days of the same kind
+type event struct {
+	first, last time.Time
+	shortened   bool
+	ordinary    bool     // только обычные выходные
+	holidays    []string // названия праздников в порядке дат
+}
+
+// maxLineOctets is the maximum length of a content line without line break
+const maxLineOctets = 75
+
+// Encode writes VCALENDAR with all-day events for days off and shortened days of days
+// starting at start, e.g. the result of GetBy or GetByPeriod. Consecutive days are merged
+// into one event. CountryCode and SixDayWeek of params should match the request; the
+// country and the first day of the event make up its UID, which stays the same between
+// exports as long as the days before start are passed with WithPrevious.
+func Encode(w io.Writer, start time.Time, days []isdayoff.DayType, params isdayoff.Params, opts ...Option) error {
+	e := encoder{stamp: time.Now()}
+	for _, opt := range opts {
+		opt(&e)
+	}
+
+	cc := isdayoff.CountryCodeRussia
+	if params.CountryCode != nil {
+		cc = *params.CountryCode
+	}
+
+	bw := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(bw, name+":"+value)
+	}
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", "-//kotopheiop//isdayoff//RU")
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if e.name != "" {
+		line("X-WR-CALNAME", escape(e.name))
+	}
+	first := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, time.UTC)
+	all := append(slices.Clip(e.previous), days...)
+	for _, ev := range events(first.AddDate(0, 0, -len(e.previous)), all, params) {
+		if ev.last.Before(first) || (ev.ordinary && !e.weekends) {
+			continue
+		}
+		kind := "off"
+		if ev.shortened {
+			kind = "short"
+		}
+		line("BEGIN", "VEVENT")
+		line("UID", fmt.Sprintf("%s-%s-%s@isdayoff.ru", ev.first.Format("20060102"), kind, cc))
+		line("DTSTAMP", e.stamp.UTC().Format("20060102T150405Z"))
+		line("DTSTART;VALUE=DATE", ev.first.Format("20060102"))
+		line("DTEND;VALUE=DATE", ev.last.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY", escape(ev.summary()))
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+	line("END", "VCALENDAR")
+	return bw.Flush()
+}
+
+// EncodeCalendar writes the calendar in iCalendar format, see Encode
+func EncodeCalendar(w io.Writer, cal *isdayoff.Calendar, opts ...Option) error {
+	cc := cal.CountryCode
+	sixDayWeek := cal.SixDayWeek
+	return Encode(w, cal.Start, cal.Days, isdayoff.Params{CountryCode: &cc, SixDayWeek: &sixDayWeek}, opts...)
+}
+
+// events merges consecutive days off and consecutive shortened days into events
+func events(start time.Time, days []isdayoff.DayType, params isdayoff.Params) []*event {
+	var result []*event
+	var current *event
+	for _, info := range isdayoff.Annotate(start, days, params) {
+		off, shortened := info.Type.IsOff(), info.Type.IsShortened()
+		if !off && !shortened {
+			current = nil
+			continue
+		}
+		if current == nil || current.shortened != shortened {
+			current = &event{first: info.Date, shortened: shortened, ordinary: true}
+			result = append(result, current)
+		}
+		current.last = info.Date
+		if info.Kind != isdayoff.DayKindWeekend {
+			current.ordinary = false
+		}
+		if info.Holiday != nil && !slices.Contains(current.holidays, info.Holiday.NameRU) {
+			current.holidays = append(current.holidays, info.Holiday.NameRU)
+		}
+	}
+	return result
+}
+
+// summary returns title of the event
+func (ev *event) summary() string {
+	var title string
+	switch {
+	case ev.shortened:
+		title = "Сокращённый рабочий день"
+	case ev.first.Equal(ev.last):
+		title = "Нерабочий день"
+	default:
+		title = "Нерабочие дни"
+	}
+	if len(ev.holidays) > 0 {
+		title += ": " + strings.Join(ev.holidays, ", ")
+	}
+	return title
+}
+
+// escape escapes TEXT value according to RFC 5545, section 3.3.11
+func escape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\n", `\n`).Replace(s)
+}
+
+// writeLine writes the content line folded at 75 octets without splitting
+// UTF-8 characters, as required by RFC 5545, section 3.1
+func writeLine(w *bufio.Writer, s string) {
+	limit := maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		// не разрываем многобайтовый символ UTF-8
+		for cut > 0 && s[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		w.WriteString(s[:cut])
+		w.WriteString("\r\n ")
+		s = s[cut:]
+		// пробел в начале строки продолжения входит в её длину
+		limit = maxLineOctets - 1
+	}
+	w.WriteString(s)
+	w.WriteString("\r\n")
+}
@@ -0,0 +1,155 @@
+package ical
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+var update = flag.Bool("update", false, "обновить эталонные файлы в testdata")
+
+var stamp = time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+// golden сравнивает результат с эталонным файлом из testdata
+func golden(t *testing.T, name string, got []byte) {
+	t.Helper()
+	path := filepath.Join("testdata", name)
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, expected) {
+		t.Errorf("output differs from %s, run go test -update to regenerate\n%s", path, got)
+	}
+}
+
+// fixture возвращает встроенный производственный календарь РФ с сокращёнными днями
+func fixture(t *testing.T, year int) []isdayoff.DayType {
+	t.Helper()
+	key := isdayoff.YearKey{Year: year, CountryCode: isdayoff.CountryCodeRussia, Pre: true}
+	days, ok, err := isdayoff.Embedded{}.Get(context.Background(), key)
+	if !ok || err != nil {
+		t.Fatalf("Embedded.Get(%d) = %v, %v", year, ok, err)
+	}
+	return days
+}
+
+func TestEncodeYear(t *testing.T) {
+	cal := &isdayoff.Calendar{
+		Start:       time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+		CountryCode: isdayoff.CountryCodeRussia,
+		Pre:         true,
+		Days:        fixture(t, 2024),
+	}
+	var buf bytes.Buffer
+	if err := EncodeCalendar(&buf, cal, WithName("Производственный календарь РФ, 2024"), WithStamp(stamp)); err != nil {
+		t.Fatalf("EncodeCalendar() failed: %v", err)
+	}
+	golden(t, "ru-2024.ics", buf.Bytes())
+}
+
+func TestEncodeWithWeekends(t *testing.T) {
+	cc := isdayoff.CountryCodeKazakhstan
+	days := []isdayoff.DayType{"0", "1", "1", "1", "1", "0", "2", "0", "0", "1", "1"}
+	var buf bytes.Buffer
+	err := Encode(&buf, time.Date(2024, time.March, 20, 0, 0, 0, 0, time.UTC), days, isdayoff.Params{CountryCode: &cc},
+		WithName(`Казахстан; март\апрель`), WithStamp(stamp), WithWeekends())
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	golden(t, "kz-2024-03.ics", buf.Bytes())
+}
+
+func TestStableUIDs(t *testing.T) {
+	encode := func(start time.Time, days []isdayoff.DayType) map[string]bool {
+		var buf bytes.Buffer
+		if err := Encode(&buf, start, days, isdayoff.Params{}); err != nil {
+			t.Fatalf("Encode() failed: %v", err)
+		}
+		uids := map[string]bool{}
+		for _, line := range strings.Split(buf.String(), "\r\n") {
+			if uid, ok := strings.CutPrefix(line, "UID:"); ok {
+				uids[uid] = true
+			}
+		}
+		return uids
+	}
+	year := fixture(t, 2024)
+	// дни мая в выгрузке года и выгрузке со 2 по 31 мая получают те же UID
+	full := encode(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), year)
+	may := encode(time.Date(2024, time.May, 2, 0, 0, 0, 0, time.UTC), year[122:152])
+	if len(may) != 2 {
+		t.Errorf("May has %d events, expected 2: %v", len(may), may)
+	}
+	for uid := range may {
+		if !full[uid] {
+			t.Errorf("UID %s of May is missing in the whole year", uid)
+		}
+	}
+	if !may["20240508-short-ru@isdayoff.ru"] || !may["20240509-off-ru@isdayoff.ru"] {
+		t.Errorf("unexpected UIDs %v", may)
+	}
+
+	// выгрузка с 10 мая начинается посреди праздников 9–12 мая: с предшествующими
+	// днями событие сохраняет настоящее начало и UID
+	var buf bytes.Buffer
+	err := Encode(&buf, time.Date(2024, time.May, 10, 0, 0, 0, 0, time.UTC), year[130:152], isdayoff.Params{},
+		WithPrevious(year[:130]), WithStamp(stamp))
+	if err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.Contains(out, "UID:20240509-off-ru@isdayoff.ru\r\nDTSTAMP:20240101T120000Z\r\nDTSTART;VALUE=DATE:20240509\r\n") {
+		t.Errorf("event running at the start of the export lost its first day:\n%s", out)
+	}
+	if strings.Count(out, "BEGIN:VEVENT") != 1 {
+		t.Errorf("export from May 10 has %d events, expected 1:\n%s", strings.Count(out, "BEGIN:VEVENT"), out)
+	}
+
+	// новогодние каникулы начинаются 30 декабря предыдущего года
+	buf.Reset()
+	if err := Encode(&buf, time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), year[:31], isdayoff.Params{},
+		WithPrevious(fixture(t, 2023)), WithStamp(stamp)); err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	if !strings.Contains(buf.String(), "UID:20231230-off-ru@isdayoff.ru\r\n") {
+		t.Errorf("New Year holidays do not start on 2023-12-30:\n%s", buf.String())
+	}
+}
+
+func TestLineFolding(t *testing.T) {
+	name := strings.Repeat("Очень длинное название календаря ", 10)
+	var buf bytes.Buffer
+	if err := Encode(&buf, stamp, []isdayoff.DayType{"1"}, isdayoff.Params{}, WithName(name), WithStamp(stamp)); err != nil {
+		t.Fatalf("Encode() failed: %v", err)
+	}
+	out := buf.String()
+	if !strings.HasSuffix(out, "\r\n") || strings.Contains(strings.ReplaceAll(out, "\r\n", ""), "\n") {
+		t.Error("lines must end with CRLF")
+	}
+	for _, line := range strings.Split(strings.TrimSuffix(out, "\r\n"), "\r\n") {
+		if len(line) > maxLineOctets {
+			t.Errorf("line is %d octets long: %q", len(line), line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("folding split a UTF-8 character: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(out, "\r\n ", "")
+	if !strings.Contains(unfolded, "X-WR-CALNAME:"+name+"\r\n") {
+		t.Error("unfolded output does not contain the name")
+	}
+}
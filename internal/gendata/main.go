@@ -0,0 +1,94 @@
+// Command gendata refreshes production calendars embedded in the isdayoff package.
+// It is run by go generate in the module root:
+//
+//	go generate ./...
+//
+// For every country and year it requests the calendar with pre=1 and covid=1
+// for the five-day and six-day week and writes it to <out>/<cc>-<year>.txt and
+// <out>/<cc>-<year>-sd.txt. Years the API has no data for are skipped, but every
+// country must get at least one year with calendars for both weeks.
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+var countries = []isdayoff.CountryCode{
+	isdayoff.CountryCodeBelarus,
+	isdayoff.CountryCodeKazakhstan,
+	isdayoff.CountryCodeRussia,
+	isdayoff.CountryCodeUkraine,
+	isdayoff.CountryCodeUSA,
+	isdayoff.CountryCodeUzbekistan,
+	isdayoff.CountryCodeTurkey,
+}
+
+func main() {
+	out := flag.String("out", "data", "directory to write calendars to")
+	from := flag.Int("from", 2013, "first year")
+	to := flag.Int("to", time.Now().Year()+1, "last year")
+	baseURL := flag.String("base-url", isdayoff.DefaultBaseURL, "API base URL")
+	flag.Parse()
+
+	client := isdayoff.New(
+		isdayoff.WithBaseURL(*baseURL),
+		isdayoff.WithRetry(isdayoff.DefaultRetryPolicy()),
+		isdayoff.WithRateLimit(isdayoff.RateLimit{RequestsPerSecond: 2, Burst: 1}),
+	)
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		log.Fatal(err)
+	}
+
+	pre, covid := true, true
+	for _, cc := range countries {
+		complete := 0
+		for year := *from; year <= *to; year++ {
+			written := 0
+			for _, sd := range []bool{false, true} {
+				days, err := client.GetBy(isdayoff.Params{Year: year, CountryCode: &cc, Pre: &pre, Covid: &covid, SixDayWeek: &sd})
+				var apiErr *isdayoff.APIError
+				if errors.As(err, &apiErr) && apiErr.Code == isdayoff.ErrorCodeNotFound {
+					continue
+				}
+				if err != nil {
+					log.Fatalf("%s %d: %v", cc, year, err)
+				}
+				if err := write(*out, cc, year, sd, days); err != nil {
+					log.Fatal(err)
+				}
+				written++
+			}
+			if written == 1 {
+				log.Fatalf("%s %d: the API returned the calendar for only one of the weeks", cc, year)
+			}
+			if written == 2 {
+				complete++
+			}
+		}
+		if complete == 0 {
+			log.Fatalf("%s: no calendars from %d to %d", cc, *from, *to)
+		}
+	}
+}
+
+// write stores the year calendar in the format of the API response
+func write(dir string, cc isdayoff.CountryCode, year int, sd bool, days []isdayoff.DayType) error {
+	name := fmt.Sprintf("%s-%04d", cc, year)
+	if sd {
+		name += "-sd"
+	}
+	var b strings.Builder
+	for _, day := range days {
+		b.WriteString(string(day))
+	}
+	return os.WriteFile(filepath.Join(dir, name+".txt"), []byte(b.String()), 0o644)
+}
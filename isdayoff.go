@@ -1,10 +1,11 @@
 package isdayoff
 
 import (
+	"context"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -12,42 +13,68 @@ import (
 // Client for requests to isdayoff.ru
 type Client struct {
 	httpClient *http.Client
+	doer       *http.Client
+	baseURL    string
+	userAgent  string
+	timeout    time.Duration
+	defaults   Params
+	middleware []Middleware
+	retry      *RetryPolicy
+	limit      *RateLimit
+	limiter    *limiter
+	clock      Clock
+	caches     []Cache
+	offline    error // ошибка для отсутствующих лет, если запросы в сеть запрещены
+	flights    flightGroup
+
+	rangeConcurrency int
 }
 
-// New initiates client with default http client
-func New() *Client {
-	return NewWithClient(http.DefaultClient)
+// New initiates client with default http client and applies options
+func New(opts ...Option) *Client {
+	c := &Client{
+		httpClient: http.DefaultClient,
+		baseURL:    DefaultBaseURL,
+		userAgent:  defaultUserAgent,
+		clock:      systemClock{},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	c.doer = chainClient(c.httpClient, c.middleware)
+	if c.limit != nil {
+		c.limiter = newLimiter(*c.limit, c.clock)
+	}
+	return c
 }
 
 // NewWithClient initiates client with custom http client
-func NewWithClient(client *http.Client) *Client {
-	return &Client{client}
+func NewWithClient(client *http.Client, opts ...Option) *Client {
+	return New(append([]Option{WithHTTPClient(client)}, opts...)...)
 }
 
 // IsLeap checks if year is leap
 func (c *Client) IsLeap(year int) (bool, error) {
-	url := fmt.Sprintf("https://isdayoff.ru/api/isleap?year=%d", year)
-	req, err := http.NewRequest(http.MethodGet, url, nil)
-	if err != nil {
-		return false, fmt.Errorf("http.NewRequest failed: %w", err)
+	return c.IsLeapContext(context.Background(), year)
+}
+
+// IsLeapContext checks if year is leap using the provided context
+func (c *Client) IsLeapContext(ctx context.Context, year int) (bool, error) {
+	if err := validateYear(year); err != nil {
+		return false, err
 	}
-	req.Header.Set("User-Agent", "isdayoff-golang-lib/1.0.0 (https://github.com/kotopheiop)")
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return false, fmt.Errorf("client.Do(req) failed: %w", err)
+	if c.offline != nil {
+		return daysIn(year) == 366, nil
 	}
-	defer res.Body.Close()
+	q := url.Values{}
+	q.Set("year", strconv.Itoa(year))
 
-	body, err := io.ReadAll(res.Body)
+	body, err := c.get(ctx, "/api/isleap", q)
 	if err != nil {
-		return false, fmt.Errorf("io.ReadAll failed: %w", err)
+		return false, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return false, parseAPIError(res.StatusCode, body)
-	}
-
-	return YearType(string(body)) == YearTypeLeap, nil
+	return YearType(strings.TrimSpace(string(body))) == YearTypeLeap, nil
 }
 
 var boolToStr = map[bool]string{
@@ -107,184 +134,150 @@ type Params struct {
 	TZ          *string
 }
 
+// values encodes filters shared by all data endpoints (cc, pre, covid, sd, tz)
+func (p Params) values() url.Values {
+	q := url.Values{}
+	if p.CountryCode != nil {
+		q.Set("cc", string(*p.CountryCode))
+	}
+	if p.Pre != nil {
+		q.Set("pre", boolToStr[*p.Pre])
+	}
+	if p.Covid != nil {
+		q.Set("covid", boolToStr[*p.Covid])
+	}
+	if p.SixDayWeek != nil {
+		q.Set("sd", boolToStr[*p.SixDayWeek])
+	}
+	if p.TZ != nil {
+		q.Set("tz", *p.TZ)
+	}
+	return q
+}
+
 // GetBy Get data by particular params
 func (c *Client) GetBy(params Params) ([]DayType, error) {
-	baseURL := "https://isdayoff.ru/api/getdata"
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	return c.GetByContext(context.Background(), params)
+}
+
+// GetByContext Get data by particular params using the provided context
+func (c *Client) GetByContext(ctx context.Context, params Params) ([]DayType, error) {
+	params = params.withDefaults(c.defaults)
+	if err := params.Validate(); err != nil {
+		return nil, err
 	}
+	if c.cached() {
+		if days, ok, err := c.cachedGetBy(ctx, params); ok {
+			return days, err
+		}
+	}
+	return c.getBy(ctx, params)
+}
 
-	q := u.Query()
+// getBy requests data by params from the API
+func (c *Client) getBy(ctx context.Context, params Params) ([]DayType, error) {
+	q := params.values()
 	q.Set("year", fmt.Sprintf("%d", params.Year))
-
 	if params.Month != nil {
 		q.Set("month", fmt.Sprintf("%02d", *params.Month))
 	}
 	if params.Day != nil {
 		q.Set("day", fmt.Sprintf("%02d", *params.Day))
 	}
-	if params.CountryCode != nil {
-		q.Set("cc", string(*params.CountryCode))
-	}
-	if params.Pre != nil {
-		q.Set("pre", boolToStr[*params.Pre])
-	}
-	if params.Covid != nil {
-		q.Set("covid", boolToStr[*params.Covid])
-	}
-	if params.SixDayWeek != nil {
-		q.Set("sd", boolToStr[*params.SixDayWeek])
-	}
-	if params.TZ != nil {
-		q.Set("tz", *params.TZ)
-	}
 
-	u.RawQuery = q.Encode()
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	body, err := c.get(ctx, "/api/getdata", q)
 	if err != nil {
-		return nil, fmt.Errorf("http.NewRequest failed: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "isdayoff-golang-lib/1.0.0 (https://github.com/kotopheiop)")
-
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("client.Do(req) failed: %w", err)
-	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
-	if err != nil {
-		return nil, fmt.Errorf("io.ReadAll failed: %w", err)
-	}
-
-	if res.StatusCode != http.StatusOK {
-		return nil, parseAPIError(res.StatusCode, body)
-	}
-	result := []DayType{}
-
-	bodyStr := string(body)
-	for _, char := range bodyStr {
-		result = append(result, DayType(string(char)))
+		return nil, err
 	}
 
-	return result, nil
+	return parseDays(body)
 }
 
+const (
+	// periodLayout is the date format of GetByPeriod arguments
+	periodLayout = "20060102"
+	// maxPeriodDays is the longest period the API returns in one request
+	maxPeriodDays = 366
+	// defaultTZ is the time zone used by the API when tz is not set
+	defaultTZ = "Europe/Moscow"
+)
+
 // GetByPeriod Get data for arbitrary period (date1 to date2)
-// Maximum 366 days can be requested
+// Maximum 366 days can be requested, use GetRange for longer periods
 // date1 and date2 should be in format YYYYMMDD
 func (c *Client) GetByPeriod(date1, date2 string, params Params) ([]DayType, error) {
-	baseURL := "https://isdayoff.ru/api/getdata"
-	u, err := url.Parse(baseURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse base URL: %w", err)
-	}
-
-	q := u.Query()
-	q.Set("date1", date1)
-	q.Set("date2", date2)
-
-	if params.CountryCode != nil {
-		q.Set("cc", string(*params.CountryCode))
-	}
-	if params.Pre != nil {
-		q.Set("pre", boolToStr[*params.Pre])
-	}
-	if params.Covid != nil {
-		q.Set("covid", boolToStr[*params.Covid])
-	}
-	if params.SixDayWeek != nil {
-		q.Set("sd", boolToStr[*params.SixDayWeek])
-	}
-
-	u.RawQuery = q.Encode()
-	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
-	if err != nil {
-		return nil, fmt.Errorf("http.NewRequest failed: %w", err)
-	}
-
-	req.Header.Set("User-Agent", "isdayoff-golang-lib/1.0.0 (https://github.com/kotopheiop)")
+	return c.GetByPeriodContext(context.Background(), date1, date2, params)
+}
 
-	res, err := c.httpClient.Do(req)
+// GetByPeriodContext Get data for arbitrary period using the provided context.
+// Malformed or nonexistent dates are reported as ValidationError without a request.
+func (c *Client) GetByPeriodContext(ctx context.Context, date1, date2 string, params Params) ([]DayType, error) {
+	from, err := parseDate("date1", date1)
 	if err != nil {
-		return nil, fmt.Errorf("client.Do(req) failed: %w", err)
+		return nil, err
 	}
-	defer res.Body.Close()
-
-	body, err := io.ReadAll(res.Body)
+	to, err := parseDate("date2", date2)
 	if err != nil {
-		return nil, fmt.Errorf("io.ReadAll failed: %w", err)
+		return nil, err
 	}
+	return c.GetByDatesContext(ctx, from, to, params)
+}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, parseAPIError(res.StatusCode, body)
-	}
+// parseDays converts API response into day types, one per character.
+// Unknown characters are reported as DayTypeError.
+func parseDays(body []byte) ([]DayType, error) {
 	result := []DayType{}
-
-	bodyStr := string(body)
-	for _, char := range bodyStr {
-		result = append(result, DayType(string(char)))
+	for _, char := range strings.TrimSpace(string(body)) {
+		day := DayType(string(char))
+		if !day.Valid() {
+			return nil, &DayTypeError{Value: string(char), Index: len(result)}
+		}
+		result = append(result, day)
 	}
-
 	return result, nil
 }
 
 // Today get data for today by particular params
 func (c *Client) Today(params Params) (*DayType, error) {
-	return c.aliasRequest("today", params)
+	return c.TodayContext(context.Background(), params)
+}
+
+// TodayContext get data for today using the provided context
+func (c *Client) TodayContext(ctx context.Context, params Params) (*DayType, error) {
+	return c.aliasRequest(ctx, "today", 0, params)
 }
 
 // Tomorrow get data for tomorrow by particular params
 func (c *Client) Tomorrow(params Params) (*DayType, error) {
-	return c.aliasRequest("tomorrow", params)
+	return c.TomorrowContext(context.Background(), params)
 }
 
-func (c *Client) aliasRequest(alias string, params Params) (*DayType, error) {
-	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("https://isdayoff.ru/%s", alias), nil)
-	if err != nil {
-		return nil, fmt.Errorf("http.NewRequest failed: %w", err)
-	}
+// TomorrowContext get data for tomorrow using the provided context
+func (c *Client) TomorrowContext(ctx context.Context, params Params) (*DayType, error) {
+	return c.aliasRequest(ctx, "tomorrow", 1, params)
+}
 
-	q := req.URL.Query()
-	if params.CountryCode != nil {
-		q.Add("cc", string(*params.CountryCode))
-	}
-	if params.Pre != nil {
-		q.Add("pre", boolToStr[*params.Pre])
-	}
-	if params.Covid != nil {
-		q.Add("covid", boolToStr[*params.Covid])
+// aliasRequest requests day shifted by offset days from today
+func (c *Client) aliasRequest(ctx context.Context, alias string, offset int, params Params) (*DayType, error) {
+	params = params.withDefaults(c.defaults)
+	if err := params.validate(false); err != nil {
+		return nil, err
 	}
-	if params.SixDayWeek != nil {
-		q.Add("sd", boolToStr[*params.SixDayWeek])
-	}
-	if params.TZ != nil {
-		q.Add("tz", *params.TZ)
-	}
-
-	req.URL.RawQuery = q.Encode()
-
-	req.Header.Set("User-Agent", "isdayoff-golang-lib/1.0.2 (https://github.com/kotopheiop)")
-
-	res, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("client.Do(req) failed: %w", err)
+	if c.cached() {
+		if day, ok, err := c.cachedAlias(ctx, offset, params); ok {
+			return day, err
+		}
 	}
-	defer res.Body.Close()
 
-	body, err := io.ReadAll(res.Body)
+	body, err := c.get(ctx, "/"+alias, params.values())
 	if err != nil {
-		return nil, fmt.Errorf("io.ReadAll failed: %w", err)
+		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return nil, parseAPIError(res.StatusCode, body)
+	result := DayType(strings.TrimSpace(string(body)))
+	if !result.Valid() {
+		return nil, &DayTypeError{Value: string(result), Index: 0}
 	}
 
-	bodyStr := strings.TrimSpace(string(body))
-	result := DayType(bodyStr)
-
 	return &result, nil
 }
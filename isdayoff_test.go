@@ -92,14 +92,8 @@ func TestGetByYear(t *testing.T) {
 				t.Errorf("GetBy(Year: %d) returned %d days, expected %d. %s", tt.year, len(days), tt.expectedDays, tt.description)
 			}
 			// Проверяем, что все дни имеют валидный тип
-			validTypes := map[DayType]bool{
-				DayTypeWorking:      true,
-				DayTypeNonWorking:   true,
-				DayTypeHalfHoliday:  true,
-				DayTypeWorkingCovid: true,
-			}
 			for i, day := range days {
-				if !validTypes[day] {
+				if !day.Valid() {
 					t.Errorf("GetBy(Year: %d) returned invalid day type at index %d: %v", tt.year, i, day)
 				}
 			}
@@ -173,13 +167,7 @@ func TestGetByDay(t *testing.T) {
 			}
 			// Проверяем, что день имеет валидный тип
 			if len(days) > 0 {
-				validTypes := map[DayType]bool{
-					DayTypeWorking:      true,
-					DayTypeNonWorking:   true,
-					DayTypeHalfHoliday:  true,
-					DayTypeWorkingCovid: true,
-				}
-				if !validTypes[days[0]] {
+				if !days[0].Valid() {
 					t.Errorf("GetBy() returned invalid day type: %v", days[0])
 				}
 				t.Logf("Day type for %d-%02d-%02d in %s: %v", tt.year, tt.month, tt.day, tt.countryCode, days[0])
@@ -238,14 +226,8 @@ func TestGetByMonth(t *testing.T) {
 				t.Errorf("GetBy() returned %d days, expected %d. %s", len(days), tt.expectedDays, tt.description)
 			}
 			// Проверяем, что все дни имеют валидный тип
-			validTypes := map[DayType]bool{
-				DayTypeWorking:      true,
-				DayTypeNonWorking:   true,
-				DayTypeHalfHoliday:  true,
-				DayTypeWorkingCovid: true,
-			}
 			for i, day := range days {
-				if !validTypes[day] {
+				if !day.Valid() {
 					t.Errorf("GetBy() returned invalid day type at index %d: %v", i, day)
 				}
 			}
@@ -312,23 +294,11 @@ func TestToday(t *testing.T) {
 			}
 
 			// Проверяем, что получили валидный тип дня
-			validTypes := map[DayType]bool{
-				DayTypeWorking:      true,
-				DayTypeNonWorking:   true,
-				DayTypeHalfHoliday:  true,
-				DayTypeWorkingCovid: true,
-			}
-			if !validTypes[*day] {
+			if !day.Valid() {
 				t.Errorf("Today() returned invalid day type: %v. %s", *day, tt.description)
 			}
 
-			dayName := map[DayType]string{
-				DayTypeWorking:      "рабочий день",
-				DayTypeNonWorking:   "нерабочий день",
-				DayTypeHalfHoliday:  "сокращенный день",
-				DayTypeWorkingCovid: "рабочий день (COVID)",
-			}
-			t.Logf("Сегодня в %s: %s (%v)", tt.countryCode, dayName[*day], *day)
+			t.Logf("Сегодня в %s: %s (%v)", tt.countryCode, day.StringRU(), string(*day))
 		})
 	}
 }
@@ -381,23 +351,11 @@ func TestTomorrow(t *testing.T) {
 			}
 
 			// Проверяем, что получили валидный тип дня
-			validTypes := map[DayType]bool{
-				DayTypeWorking:      true,
-				DayTypeNonWorking:   true,
-				DayTypeHalfHoliday:  true,
-				DayTypeWorkingCovid: true,
-			}
-			if !validTypes[*day] {
+			if !day.Valid() {
 				t.Errorf("Tomorrow() returned invalid day type: %v. %s", *day, tt.description)
 			}
 
-			dayName := map[DayType]string{
-				DayTypeWorking:      "рабочий день",
-				DayTypeNonWorking:   "нерабочий день",
-				DayTypeHalfHoliday:  "сокращенный день",
-				DayTypeWorkingCovid: "рабочий день (COVID)",
-			}
-			t.Logf("Завтра в %s: %s (%v)", tt.countryCode, dayName[*day], *day)
+			t.Logf("Завтра в %s: %s (%v)", tt.countryCode, day.StringRU(), string(*day))
 		})
 	}
 }
@@ -557,14 +515,8 @@ func TestGetByPeriod(t *testing.T) {
 				t.Errorf("GetByPeriod() returned %d days, expected between %d and %d. %s", len(days), tt.expectedMin, tt.expectedMax, tt.description)
 			}
 			// Проверяем, что все дни имеют валидный тип
-			validTypes := map[DayType]bool{
-				DayTypeWorking:      true,
-				DayTypeNonWorking:   true,
-				DayTypeHalfHoliday:  true,
-				DayTypeWorkingCovid: true,
-			}
 			for i, day := range days {
-				if !validTypes[day] {
+				if !day.Valid() {
 					t.Errorf("GetByPeriod() returned invalid day type at index %d: %v", i, day)
 				}
 			}
@@ -593,14 +545,8 @@ func TestGetByWithSixDayWeek(t *testing.T) {
 	}
 
 	// Проверяем, что все дни имеют валидный тип
-	validTypes := map[DayType]bool{
-		DayTypeWorking:      true,
-		DayTypeNonWorking:   true,
-		DayTypeHalfHoliday:  true,
-		DayTypeWorkingCovid: true,
-	}
 	for i, day := range days {
-		if !validTypes[day] {
+		if !day.Valid() {
 			t.Errorf("GetBy() with SixDayWeek returned invalid day type at index %d: %v", i, day)
 		}
 	}
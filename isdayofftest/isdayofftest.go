@@ -0,0 +1,65 @@
+// Package isdayofftest provides an in-memory isdayoff.Provider for tests of code
+// built on the isdayoff package, so that they run without network.
+package isdayofftest
+
+import (
+	"context"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+// Provider answers from year calendars set by the test. Requests for other years
+// return an error wrapping isdayoff.ErrNotCached.
+type Provider struct {
+	*isdayoff.Client
+	cache *isdayoff.MemoryCache
+}
+
+// NewProvider returns a provider without data, for which it is now
+func NewProvider(now time.Time) *Provider {
+	cache := isdayoff.NewMemoryCache(0, 0)
+	return &Provider{
+		Client: isdayoff.NewCacheProvider(cache, isdayoff.WithClock(fixedClock{now})),
+		cache:  cache,
+	}
+}
+
+// SetYear sets the year calendar for requests matching key; empty CountryCode
+// means ru, as in requests to the API
+func (p *Provider) SetYear(key isdayoff.YearKey, days []isdayoff.DayType) {
+	if key.CountryCode == "" {
+		key.CountryCode = isdayoff.CountryCodeRussia
+	}
+	p.cache.Set(context.Background(), key, days)
+}
+
+// Weekends returns calendar of the year in which Saturdays, Sundays and holidays are days off
+func Weekends(year int, holidays ...time.Time) []isdayoff.DayType {
+	off := map[time.Time]bool{}
+	for _, h := range holidays {
+		off[time.Date(h.Year(), h.Month(), h.Day(), 0, 0, 0, 0, time.UTC)] = true
+	}
+	var days []isdayoff.DayType
+	for d := time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC); d.Year() == year; d = d.AddDate(0, 0, 1) {
+		if off[d] || d.Weekday() == time.Saturday || d.Weekday() == time.Sunday {
+			days = append(days, isdayoff.DayTypeNonWorking)
+		} else {
+			days = append(days, isdayoff.DayTypeWorking)
+		}
+	}
+	return days
+}
+
+// fixedClock always returns the same time; timers fire at once
+type fixedClock struct {
+	now time.Time
+}
+
+func (c fixedClock) Now() time.Time { return c.now }
+
+func (c fixedClock) After(time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+	ch <- c.now
+	return ch
+}
@@ -0,0 +1,87 @@
+package isdayoff
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Period is a range of months of a year, both included
+type Period struct {
+	First time.Month
+	Last  time.Month
+}
+
+// MonthPeriod returns period of a single month
+func MonthPeriod(month time.Month) Period {
+	return Period{First: month, Last: month}
+}
+
+// QuarterPeriod returns period of the quarter numbered from 1 to 4
+func QuarterPeriod(quarter int) Period {
+	first := time.Month((quarter-1)*3 + 1)
+	return Period{First: first, Last: first + 2}
+}
+
+// YearPeriod returns period of the whole year
+func YearPeriod() Period {
+	return Period{First: time.January, Last: time.December}
+}
+
+func (p Period) valid() bool {
+	return p.First >= time.January && p.Last <= time.December && p.First <= p.Last
+}
+
+// WorkingHoursNorm calculates the working hours norm (норма рабочего времени)
+// for the period and the length of the working week in hours, e.g. 40, 36 or 24.
+//
+// The norm follows the official procedure: the weekly hours divided by five
+// for every working day of the five-day week, minus one hour for every
+// shortened pre-holiday day. The calendar is requested with Pre=true and
+// SixDayWeek=false regardless of params.
+func (c *Client) WorkingHoursNorm(year int, period Period, weeklyHours float64, params Params) (time.Duration, error) {
+	return c.WorkingHoursNormContext(context.Background(), year, period, weeklyHours, params)
+}
+
+// WorkingHoursNormContext calculates the working hours norm using the provided context
+func (c *Client) WorkingHoursNormContext(ctx context.Context, year int, period Period, weeklyHours float64, params Params) (time.Duration, error) {
+	return WorkingHoursNorm(ctx, c, year, period, weeklyHours, params)
+}
+
+// WorkingHoursNorm calculates the working hours norm using the provider, see Client.WorkingHoursNorm
+func WorkingHoursNorm(ctx context.Context, p Provider, year int, period Period, weeklyHours float64, params Params) (time.Duration, error) {
+	if !period.valid() {
+		return 0, fmt.Errorf("invalid period %v - %v", period.First, period.Last)
+	}
+	if weeklyHours <= 0 {
+		return 0, fmt.Errorf("invalid weekly hours %v", weeklyHours)
+	}
+
+	pre := true
+	sixDayWeek := false
+	params.Pre = &pre
+	params.SixDayWeek = &sixDayWeek
+
+	cal, err := FetchCalendar(ctx, p, year, params)
+	if cal == nil {
+		return 0, err
+	}
+	first := time.Date(year, period.First, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(year, period.Last+1, 0, 0, 0, 0, 0, time.UTC)
+	days, rangeErr := cal.Range(first, last)
+	if rangeErr != nil {
+		return 0, rangeErr
+	}
+
+	daily := time.Duration(weeklyHours * float64(time.Hour) / 5)
+	var norm time.Duration
+	for _, day := range days {
+		if day.IsWorking() {
+			norm += daily
+		}
+		if day == DayTypeHalfHoliday {
+			norm -= time.Hour
+		}
+	}
+	return norm, err
+}
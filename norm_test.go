@@ -0,0 +1,104 @@
+package isdayoff
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// fixtureHandler отдаёт встроенные производственные календари РФ в формате
+// ответа getdata?year=YYYY&cc=ru&pre=1
+func fixtureHandler(t *testing.T) http.Handler {
+	t.Helper()
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("pre") != "1" || q.Get("sd") == "1" || q.Get("cc") != "ru" || q.Has("month") {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("101"))
+			return
+		}
+		data, err := embeddedData.ReadFile("data/ru-" + q.Get("year") + ".txt")
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte("101"))
+			return
+		}
+		w.Write(data)
+	})
+}
+
+// hours переводит часы с точностью до десятых в time.Duration
+func hours(h float64) time.Duration {
+	return time.Duration(math.Round(h*10)) * time.Hour / 10
+}
+
+func TestWorkingHoursNorm(t *testing.T) {
+	client := newTestClient(t, fixtureHandler(t))
+	cc := CountryCodeRussia
+	pre := false
+	sd := true
+	// Pre и SixDayWeek из параметров не влияют на расчёт нормы
+	params := Params{CountryCode: &cc, Pre: &pre, SixDayWeek: &sd}
+
+	// нормы опубликованы в производственных календарях на 2023 и 2024 годы
+	tests := []struct {
+		year     int
+		period   Period
+		weekly   float64
+		expected time.Duration
+	}{
+		{2024, YearPeriod(), 40, hours(1979)},
+		{2024, YearPeriod(), 36, hours(1780.6)},
+		{2024, YearPeriod(), 24, hours(1185.4)},
+		{2024, QuarterPeriod(1), 40, hours(454)},
+		{2024, QuarterPeriod(2), 40, hours(478)},
+		{2024, QuarterPeriod(3), 40, hours(528)},
+		{2024, QuarterPeriod(4), 40, hours(519)},
+		{2024, MonthPeriod(time.January), 40, hours(136)},
+		{2024, MonthPeriod(time.February), 40, hours(159)},
+		{2024, MonthPeriod(time.May), 40, hours(159)},
+		{2024, MonthPeriod(time.May), 36, hours(143)},
+		{2024, MonthPeriod(time.June), 40, hours(151)},
+		{2024, MonthPeriod(time.November), 40, hours(167)},
+		{2024, MonthPeriod(time.December), 40, hours(168)},
+		{2023, YearPeriod(), 40, hours(1973)},
+		{2023, YearPeriod(), 36, hours(1775.4)},
+		{2023, YearPeriod(), 24, hours(1182.6)},
+		{2023, QuarterPeriod(1), 40, hours(454)},
+		{2023, QuarterPeriod(2), 40, hours(488)},
+		{2023, QuarterPeriod(4), 40, hours(511)},
+		{2023, MonthPeriod(time.February), 40, hours(143)},
+		{2023, MonthPeriod(time.November), 40, hours(167)},
+	}
+	for _, tt := range tests {
+		name := fmt.Sprintf("%d %v-%v %vh", tt.year, tt.period.First, tt.period.Last, tt.weekly)
+		t.Run(name, func(t *testing.T) {
+			norm, err := client.WorkingHoursNorm(tt.year, tt.period, tt.weekly, params)
+			if err != nil {
+				t.Fatalf("WorkingHoursNorm(%d, %v, %v) failed: %v", tt.year, tt.period, tt.weekly, err)
+			}
+			if norm != tt.expected {
+				t.Errorf("WorkingHoursNorm(%d, %v, %v) = %v, expected %v", tt.year, tt.period, tt.weekly, norm.Hours(), tt.expected.Hours())
+			}
+		})
+	}
+}
+
+func TestWorkingHoursNormErrors(t *testing.T) {
+	client := newTestClient(t, fixtureHandler(t))
+
+	if _, err := client.WorkingHoursNorm(2024, QuarterPeriod(5), 40, Params{}); err == nil {
+		t.Error("WorkingHoursNorm() with quarter 5 should fail")
+	}
+	if _, err := client.WorkingHoursNorm(2024, Period{First: time.May, Last: time.March}, 40, Params{}); err == nil {
+		t.Error("WorkingHoursNorm() with reversed period should fail")
+	}
+	if _, err := client.WorkingHoursNorm(2024, YearPeriod(), 0, Params{}); err == nil {
+		t.Error("WorkingHoursNorm() with zero weekly hours should fail")
+	}
+	if _, err := client.WorkingHoursNorm(2010, YearPeriod(), 40, Params{}); err == nil {
+		t.Error("WorkingHoursNorm() for year without data should fail")
+	}
+}
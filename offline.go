@@ -0,0 +1,101 @@
+package isdayoff
+
+import (
+	"context"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+//go:generate go run ./internal/gendata -out data
+
+// embeddedData holds year calendars as returned by the API with pre=1 and covid=1:
+// data/<cc>-<year>.txt for the five-day week and data/<cc>-<year>-sd.txt for the six-day week
+//
+//go:embed data
+var embeddedData embed.FS
+
+// ErrNotEmbedded is returned by offline clients for years missing in the embedded data
+var ErrNotEmbedded = errors.New("year is not included in embedded data")
+
+// Embedded is a read-only Cache answering from production calendars embedded
+// in the module. Calendars without shortened days or COVID-19 marks are derived
+// from the embedded ones. Set and Delete do nothing.
+type Embedded struct{}
+
+// embeddedName returns the file name of the year calendar
+func embeddedName(key YearKey) string {
+	name := fmt.Sprintf("data/%s-%04d", key.CountryCode, key.Year)
+	if key.SixDayWeek {
+		name += "-sd"
+	}
+	return name + ".txt"
+}
+
+// Get returns the embedded year calendar, reporting false if it is not included
+func (Embedded) Get(_ context.Context, key YearKey) ([]DayType, bool, error) {
+	data, err := embeddedData.ReadFile(embeddedName(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("embed.FS.ReadFile failed: %w", err)
+	}
+	days, err := parseDays(data)
+	if err != nil {
+		return nil, false, err
+	}
+	for i, day := range days {
+		if (day == DayTypeHalfHoliday && !key.Pre) || (day == DayTypeWorkingCovid && !key.Covid) {
+			days[i] = DayTypeWorking
+		}
+	}
+	return days, true, nil
+}
+
+// Set does nothing, embedded data is read-only
+func (Embedded) Set(context.Context, YearKey, []DayType) error { return nil }
+
+// Delete does nothing, embedded data is read-only
+func (Embedded) Delete(context.Context, YearKey) error { return nil }
+
+// Years returns years of the country included in the embedded data for the five-day week
+func (Embedded) Years(cc CountryCode) []int {
+	var years []int
+	entries, _ := embeddedData.ReadDir("data")
+	for _, entry := range entries {
+		name, ok := strings.CutPrefix(entry.Name(), string(cc)+"-")
+		if !ok {
+			continue
+		}
+		if year, err := strconv.Atoi(strings.TrimSuffix(name, ".txt")); err == nil {
+			years = append(years, year)
+		}
+	}
+	slices.Sort(years)
+	return years
+}
+
+// WithOffline makes the client answer GetBy, GetByPeriod, Today, Tomorrow and IsLeap
+// from the embedded data only, without any requests. Requests for years that are
+// not included return an error wrapping ErrNotEmbedded. Other cache layers added
+// with WithCache are consulted before the embedded data.
+func WithOffline() Option {
+	return func(c *Client) {
+		c.offline = ErrNotEmbedded
+		c.caches = append(c.caches, Embedded{})
+	}
+}
+
+// missing reports the year calendar missing in the data of an offline client
+func (c *Client) missing(key YearKey) error {
+	week := "five-day"
+	if key.SixDayWeek {
+		week = "six-day"
+	}
+	return fmt.Errorf("%s %d (%s week): %w", key.CountryCode, key.Year, week, c.offline)
+}
@@ -0,0 +1,113 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOfflineClient(t *testing.T) {
+	api, _ := newFakeAPI(t)
+	clock := newFakeClock(time.Date(2024, time.May, 8, 22, 30, 0, 0, time.UTC)) // 9 мая по Москве
+	client := newTestClient(t, api, WithOffline(), WithClock(clock))
+
+	fixture, err := embeddedData.ReadFile("data/ru-2024.txt")
+	if err != nil {
+		t.Fatal(err)
+	}
+	pre := true
+	days, err := client.GetBy(Params{Year: 2024, Pre: &pre})
+	if err != nil || string(joinDays(days)) != strings.TrimSpace(string(fixture)) {
+		t.Errorf("GetBy(2024) = %d days, %v", len(days), err)
+	}
+
+	// без pre сокращённые дни становятся обычными рабочими
+	month := time.November
+	days, err = client.GetBy(Params{Year: 2024, Month: &month})
+	if err != nil || days[1] != DayTypeWorking || slices.Contains(days, DayTypeHalfHoliday) {
+		t.Errorf("GetBy(2024-11) = %v, %v", days, err)
+	}
+
+	days, err = client.GetByPeriod("20231229", "20240102", Params{})
+	if err != nil || !slices.Equal(days, []DayType{"0", "1", "1", "1", "1"}) {
+		t.Errorf("GetByPeriod() across years = %v, %v", days, err)
+	}
+
+	day, err := client.Today(Params{})
+	if err != nil || *day != DayTypeNonWorking {
+		t.Errorf("Today() = %v, %v; expected Victory Day off", day, err)
+	}
+	day, err = client.Tomorrow(Params{Pre: &pre})
+	if err != nil || *day != DayTypeNonWorking {
+		t.Errorf("Tomorrow() = %v, %v", day, err)
+	}
+	if leap, err := client.IsLeap(2100); err != nil || leap {
+		t.Errorf("IsLeap(2100) = %v, %v", leap, err)
+	}
+	if _, err := client.AddWorkingDays(date(2024, time.December, 28), 2, Params{}); !errors.Is(err, ErrNotEmbedded) {
+		t.Errorf("AddWorkingDays() into 2025 error = %v, expected ErrNotEmbedded", err)
+	}
+
+	kz := CountryCodeKazakhstan
+	sd := true
+	for _, params := range []Params{{Year: 2022}, {Year: 2024, CountryCode: &kz}, {Year: 2024, SixDayWeek: &sd}} {
+		if _, err := client.GetBy(params); !errors.Is(err, ErrNotEmbedded) {
+			t.Errorf("GetBy(%+v) error = %v, expected ErrNotEmbedded", params, err)
+		}
+	}
+	if got := api.requestCount(); got != 0 {
+		t.Errorf("offline client made %d requests: %v", got, api.requests)
+	}
+}
+
+func TestOfflineWithCache(t *testing.T) {
+	client := newTestClient(t, http404(), WithCache(NewMemoryCache(0, 0)), WithOffline())
+	if _, err := client.GetBy(Params{Year: 2023}); err != nil {
+		t.Fatalf("GetBy(2023) failed: %v", err)
+	}
+	// календарь из встроенных данных попадает в кэш перед ними
+	mem := client.caches[0].(*MemoryCache)
+	if stats := mem.Stats(); stats.Entries != 1 {
+		t.Errorf("memory cache has %d entries, expected 1", stats.Entries)
+	}
+}
+
+func TestEmbeddedData(t *testing.T) {
+	if years := (Embedded{}).Years(CountryCodeRussia); !slices.Contains(years, 2023) || !slices.Contains(years, 2024) {
+		t.Errorf("Years(ru) = %v", years)
+	}
+	entries, err := embeddedData.ReadDir("data")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, entry := range entries {
+		cc, year, _ := strings.Cut(strings.TrimSuffix(strings.TrimSuffix(entry.Name(), ".txt"), "-sd"), "-")
+		y, err := strconv.Atoi(year)
+		if err != nil || !CountryCode(cc).Valid() {
+			t.Errorf("unexpected file %s", entry.Name())
+			continue
+		}
+		data, _ := embeddedData.ReadFile("data/" + entry.Name())
+		days, err := parseDays(data)
+		if err != nil || len(days) != daysIn(y) {
+			t.Errorf("%s has %d days, %v", entry.Name(), len(days), err)
+		}
+	}
+
+	var cache Cache = Embedded{}
+	if _, ok, _ := cache.Get(context.Background(), YearKey{Year: 1990, CountryCode: CountryCodeRussia}); ok {
+		t.Error("Get() returned a year that is not embedded")
+	}
+}
+
+func joinDays(days []DayType) []byte {
+	var b []byte
+	for _, day := range days {
+		b = append(b, day...)
+	}
+	return b
+}
@@ -0,0 +1,82 @@
+package isdayoff
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the address of the public isdayoff.ru API
+const DefaultBaseURL = "https://isdayoff.ru"
+
+const defaultUserAgent = "isdayoff-golang-lib/1.0.2 (https://github.com/kotopheiop)"
+
+// Option configures Client
+type Option func(*Client)
+
+// WithBaseURL sets the API address, e.g. a mirror, a proxy or a local test server
+func WithBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.baseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHTTPClient sets http client used for requests
+func WithHTTPClient(client *http.Client) Option {
+	return func(c *Client) {
+		if client != nil {
+			c.httpClient = client
+		}
+	}
+}
+
+// WithUserAgent sets User-Agent header sent with every request
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.userAgent = userAgent
+	}
+}
+
+// WithTimeout limits the duration of every request made by the client.
+// Zero means no limit besides the one set by the context and http client.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = timeout
+	}
+}
+
+// WithDefaultParams sets params used for fields not set in a particular request
+func WithDefaultParams(params Params) Option {
+	return func(c *Client) {
+		c.defaults = params
+	}
+}
+
+// withDefaults fills unset fields of params from defaults
+func (p Params) withDefaults(defaults Params) Params {
+	if p.Year == 0 {
+		p.Year = defaults.Year
+	}
+	if p.Month == nil {
+		p.Month = defaults.Month
+	}
+	if p.Day == nil {
+		p.Day = defaults.Day
+	}
+	if p.CountryCode == nil {
+		p.CountryCode = defaults.CountryCode
+	}
+	if p.Pre == nil {
+		p.Pre = defaults.Pre
+	}
+	if p.Covid == nil {
+		p.Covid = defaults.Covid
+	}
+	if p.SixDayWeek == nil {
+		p.SixDayWeek = defaults.SixDayWeek
+	}
+	if p.TZ == nil {
+		p.TZ = defaults.TZ
+	}
+	return p
+}
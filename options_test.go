@@ -0,0 +1,110 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNewWithOptions(t *testing.T) {
+	httpClient := &http.Client{}
+	client := New(
+		WithBaseURL("http://localhost:8080/"),
+		WithHTTPClient(httpClient),
+		WithUserAgent("test-agent"),
+		WithTimeout(3*time.Second),
+	)
+	if client.baseURL != "http://localhost:8080" {
+		t.Errorf("baseURL = %q, expected trailing slash to be trimmed", client.baseURL)
+	}
+	if client.httpClient != httpClient {
+		t.Error("WithHTTPClient() did not set provided HTTP client")
+	}
+	if client.userAgent != "test-agent" {
+		t.Errorf("userAgent = %q, expected %q", client.userAgent, "test-agent")
+	}
+	if client.timeout != 3*time.Second {
+		t.Errorf("timeout = %v, expected %v", client.timeout, 3*time.Second)
+	}
+
+	def := New()
+	if def.baseURL != DefaultBaseURL || def.httpClient != http.DefaultClient || def.userAgent != defaultUserAgent {
+		t.Errorf("New() without options has unexpected defaults: %+v", def)
+	}
+}
+
+func TestBaseURLEndpoints(t *testing.T) {
+	type request struct {
+		path  string
+		query string
+		agent string
+	}
+	var got request
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = request{r.URL.Path, r.URL.RawQuery, r.UserAgent()}
+		switch r.URL.Path {
+		case "/api/isleap":
+			w.Write([]byte("1"))
+		case "/api/getdata":
+			w.Write([]byte("0110"))
+		default:
+			w.Write([]byte("0"))
+		}
+	}))
+	defer srv.Close()
+
+	cc := CountryCodeKazakhstan
+	pre := true
+	client := New(
+		WithBaseURL(srv.URL),
+		WithUserAgent("test-agent"),
+		WithDefaultParams(Params{CountryCode: &cc, Pre: &pre}),
+	)
+
+	leap, err := client.IsLeap(2024)
+	if err != nil || !leap {
+		t.Fatalf("IsLeap(2024) = %v, %v", leap, err)
+	}
+	if got.path != "/api/isleap" || got.query != "year=2024" {
+		t.Errorf("IsLeap requested %s?%s", got.path, got.query)
+	}
+
+	days, err := client.GetBy(Params{Year: 2024})
+	if err != nil || len(days) != 4 {
+		t.Fatalf("GetBy() = %v, %v", days, err)
+	}
+	if got.path != "/api/getdata" || got.query != "cc=kz&pre=1&year=2024" {
+		t.Errorf("GetBy requested %s?%s", got.path, got.query)
+	}
+
+	ru := CountryCodeRussia
+	if _, err := client.GetByPeriod("20240101", "20240104", Params{CountryCode: &ru}); err != nil {
+		t.Fatalf("GetByPeriod() failed: %v", err)
+	}
+	if got.query != "cc=ru&date1=20240101&date2=20240104&pre=1" {
+		t.Errorf("GetByPeriod requested %s?%s, explicit params should override defaults", got.path, got.query)
+	}
+
+	if _, err := client.Tomorrow(Params{}); err != nil {
+		t.Fatalf("Tomorrow() failed: %v", err)
+	}
+	if got.path != "/tomorrow" || got.query != "cc=kz&pre=1" {
+		t.Errorf("Tomorrow requested %s?%s", got.path, got.query)
+	}
+	if got.agent != "test-agent" {
+		t.Errorf("User-Agent = %q, expected %q", got.agent, "test-agent")
+	}
+}
+
+func TestWithTimeout(t *testing.T) {
+	aborted := make(chan struct{})
+	client := newTestClient(t, blockingHandler(aborted), WithTimeout(50*time.Millisecond))
+
+	_, err := client.Today(Params{})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Today() error = %v, expected context.DeadlineExceeded", err)
+	}
+}
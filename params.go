@@ -20,6 +20,16 @@ const (
 	CountryCodeTurkey CountryCode = "tr"
 )
 
+// Valid reports whether the country code is supported by the API
+func (cc CountryCode) Valid() bool {
+	switch cc {
+	case CountryCodeBelarus, CountryCodeKazakhstan, CountryCodeRussia, CountryCodeUkraine,
+		CountryCodeUSA, CountryCodeUzbekistan, CountryCodeTurkey:
+		return true
+	}
+	return false
+}
+
 // DayType type
 type DayType string
 
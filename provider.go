@@ -0,0 +1,59 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+)
+
+// Provider supplies production calendar data: days of a year, month or day,
+// of a period, of today and tomorrow, and leap years. It is implemented by Client,
+// by clients answering from local data (NewCacheProvider, WithOffline) and by
+// the in-memory fake of package isdayofftest, so that code built on Provider
+// can be tested without network.
+type Provider interface {
+	// GetByContext returns days of the year, the month or the day of params
+	GetByContext(ctx context.Context, params Params) ([]DayType, error)
+	// GetByPeriodContext returns days from date1 to date2 inclusive
+	GetByPeriodContext(ctx context.Context, date1, date2 string, params Params) ([]DayType, error)
+	// IsLeapContext reports whether the year is leap
+	IsLeapContext(ctx context.Context, year int) (bool, error)
+	// TodayContext returns type of today
+	TodayContext(ctx context.Context, params Params) (*DayType, error)
+	// TomorrowContext returns type of tomorrow
+	TomorrowContext(ctx context.Context, params Params) (*DayType, error)
+}
+
+var _ Provider = (*Client)(nil)
+
+// paramsResolver is implemented by providers filling params with their defaults.
+// Helpers taking a Provider use it to interpret responses with the same country and flags.
+type paramsResolver interface {
+	resolveParams(params Params) Params
+}
+
+func (c *Client) resolveParams(params Params) Params {
+	return params.withDefaults(c.defaults)
+}
+
+// resolveParams returns params as the provider applies them
+func resolveParams(p Provider, params Params) Params {
+	if r, ok := p.(paramsResolver); ok {
+		return r.resolveParams(params)
+	}
+	return params
+}
+
+// ErrNotCached is returned by clients created with NewCacheProvider for years missing in the cache
+var ErrNotCached = errors.New("year is not in the cache")
+
+// NewCacheProvider returns a client answering only from year calendars of cache,
+// e.g. a FileCache filled in advance, without any requests. Requests for years
+// that are not in the cache return an error wrapping ErrNotCached.
+// Options configure the client as usual, e.g. WithClock sets the time Today uses.
+// WithOffline among them adds the embedded data as a layer after cache; years
+// missing from both are still reported with ErrNotCached.
+func NewCacheProvider(cache Cache, opts ...Option) *Client {
+	c := New(append([]Option{WithCache(cache)}, opts...)...)
+	c.offline = ErrNotCached
+	return c
+}
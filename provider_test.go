@@ -0,0 +1,151 @@
+package isdayoff_test
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+	"github.com/kotopheiop/isdayoff/isdayofftest"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+// бизнес-логика, зависящая только от Provider
+func deadline(ctx context.Context, p isdayoff.Provider, start time.Time) (time.Time, error) {
+	return isdayoff.AddWorkingDays(ctx, p, start, 3, isdayoff.Params{})
+}
+
+func TestHelpersAcceptProvider(t *testing.T) {
+	ctx := context.Background()
+	p := isdayofftest.NewProvider(time.Date(2024, time.May, 8, 12, 0, 0, 0, time.UTC))
+	// без кода страны календарь относится к России, как и запросы без cc
+	p.SetYear(isdayoff.YearKey{Year: 2024}, isdayofftest.Weekends(2024, date(2024, time.May, 9), date(2024, time.May, 10)))
+
+	got, err := deadline(ctx, p, date(2024, time.May, 8))
+	if err != nil || !got.Equal(date(2024, time.May, 15)) {
+		t.Errorf("deadline() = %v, %v; expected 2024-05-15", got, err)
+	}
+	if next, err := isdayoff.NextWorkingDay(ctx, p, date(2024, time.May, 8), isdayoff.Params{}); err != nil || !next.Equal(date(2024, time.May, 13)) {
+		t.Errorf("NextWorkingDay() = %v, %v", next, err)
+	}
+	if prev, err := isdayoff.PrevWorkingDay(ctx, p, date(2024, time.May, 13), isdayoff.Params{}); err != nil || !prev.Equal(date(2024, time.May, 8)) {
+		t.Errorf("PrevWorkingDay() = %v, %v", prev, err)
+	}
+	if n, err := isdayoff.CountWorkingDays(ctx, p, date(2024, time.May, 1), date(2024, time.May, 31), isdayoff.Params{}); err != nil || n != 21 {
+		t.Errorf("CountWorkingDays() = %d, %v; expected 21", n, err)
+	}
+	if norm, err := isdayoff.WorkingHoursNorm(ctx, p, 2024, isdayoff.MonthPeriod(time.May), 40, isdayoff.Params{}); err == nil {
+		// норма считается по календарю с pre=1, которого у провайдера нет
+		t.Errorf("WorkingHoursNorm() = %v, expected error for missing pre calendar", norm)
+	}
+	info, err := isdayoff.Classify(ctx, p, date(2024, time.May, 9), isdayoff.Params{})
+	if err != nil || info.Kind != isdayoff.DayKindHoliday {
+		t.Errorf("Classify() = %+v, %v", info, err)
+	}
+	cal, err := isdayoff.FetchCalendar(ctx, p, 2024, isdayoff.Params{})
+	if err != nil || len(cal.Days) != 366 {
+		t.Errorf("FetchCalendar() = %v, %v", cal, err)
+	}
+	days, err := isdayoff.GetRange(ctx, p, date(2024, time.May, 8), date(2024, time.May, 13), isdayoff.Params{})
+	if err != nil || !slices.Equal(days, []isdayoff.DayType{"0", "1", "1", "1", "1", "0"}) {
+		t.Errorf("GetRange() = %v, %v", days, err)
+	}
+	if day, err := p.TomorrowContext(ctx, isdayoff.Params{}); err != nil || *day != isdayoff.DayTypeNonWorking {
+		t.Errorf("TomorrowContext() = %v, %v", day, err)
+	}
+
+	if _, err := deadline(ctx, p, date(2024, time.December, 30)); !errors.Is(err, isdayoff.ErrNotCached) {
+		t.Errorf("deadline() into 2025 error = %v, expected ErrNotCached", err)
+	}
+}
+
+func TestCacheProvider(t *testing.T) {
+	ctx := context.Background()
+	files, err := isdayoff.NewFileCache(t.TempDir(), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := isdayoff.YearKey{Year: 2023, CountryCode: isdayoff.CountryCodeBelarus}
+	files.Set(ctx, key, isdayofftest.Weekends(2023))
+
+	var p isdayoff.Provider = isdayoff.NewCacheProvider(files)
+	cc := isdayoff.CountryCodeBelarus
+	days, err := p.GetByPeriodContext(ctx, "20231229", "20231231", isdayoff.Params{CountryCode: &cc})
+	if err != nil || !slices.Equal(days, []isdayoff.DayType{"0", "1", "1"}) {
+		t.Errorf("GetByPeriodContext() = %v, %v", days, err)
+	}
+	if _, err := p.GetByContext(ctx, isdayoff.Params{Year: 2023}); !errors.Is(err, isdayoff.ErrNotCached) {
+		t.Errorf("GetByContext() for missing country error = %v, expected ErrNotCached", err)
+	}
+	if leap, err := p.IsLeapContext(ctx, 2024); err != nil || !leap {
+		t.Errorf("IsLeapContext(2024) = %v, %v", leap, err)
+	}
+
+	// WithOffline добавляет встроенные данные после кэша
+	p = isdayoff.NewCacheProvider(isdayoff.NewMemoryCache(0, 0), isdayoff.WithOffline())
+	if days, err := p.GetByContext(ctx, isdayoff.Params{Year: 2024}); err != nil || len(days) != 366 {
+		t.Errorf("GetByContext(2024) with WithOffline = %d days, %v", len(days), err)
+	}
+	if _, err := p.GetByContext(ctx, isdayoff.Params{Year: 1990}); !errors.Is(err, isdayoff.ErrNotCached) {
+		t.Errorf("GetByContext(1990) with WithOffline error = %v, expected ErrNotCached", err)
+	}
+	// опции вызывающего не перезаписываются
+	spare := make([]isdayoff.Option, 0, 1)
+	isdayoff.NewCacheProvider(files, spare...)
+	if spare[:1][0] != nil {
+		t.Error("NewCacheProvider() wrote into the backing array of opts")
+	}
+
+	// встроенные данные тоже доступны как Provider
+	var offline isdayoff.Provider = isdayoff.NewCacheProvider(isdayoff.Embedded{})
+	if n, err := isdayoff.CountWorkingDays(ctx, offline, date(2024, time.January, 1), date(2024, time.December, 31), isdayoff.Params{}); err != nil || n != 248 {
+		t.Errorf("CountWorkingDays() from embedded data = %d, %v; expected 248", n, err)
+	}
+}
+
+// помощники применяют параметры по умолчанию провайдера так же, как его запросы
+func TestHelpersUseProviderDefaults(t *testing.T) {
+	ctx := context.Background()
+	cache := isdayoff.NewMemoryCache(0, 0)
+	kz, sd := isdayoff.CountryCodeKazakhstan, true
+	cache.Set(ctx, isdayoff.YearKey{Year: 2024, CountryCode: kz}, isdayofftest.Weekends(2024))
+	sixDays := isdayofftest.Weekends(2024)
+	for i := range sixDays {
+		if date(2024, time.January, 1).AddDate(0, 0, i).Weekday() == time.Saturday {
+			sixDays[i] = isdayoff.DayTypeWorking
+		}
+	}
+	cache.Set(ctx, isdayoff.YearKey{Year: 2024, CountryCode: kz, SixDayWeek: true}, sixDays)
+
+	p := isdayoff.NewCacheProvider(cache, isdayoff.WithDefaultParams(isdayoff.Params{CountryCode: &kz}))
+	cal, err := isdayoff.FetchCalendar(ctx, p, 2024, isdayoff.Params{})
+	if err != nil {
+		t.Fatalf("FetchCalendar() failed: %v", err)
+	}
+	if cal.CountryCode != kz {
+		t.Errorf("FetchCalendar().CountryCode = %s, expected kz", cal.CountryCode)
+	}
+	info, err := isdayoff.Classify(ctx, p, date(2024, time.July, 6), isdayoff.Params{})
+	if err != nil || info.Kind != isdayoff.DayKindHoliday || info.Holiday == nil || info.Holiday.NameEN != "Capital Day" {
+		t.Errorf("Classify(2024-07-06) = %+v, %v; expected Capital Day", info, err)
+	}
+
+	// при шестидневной неделе по умолчанию суббота — обычный рабочий день
+	p = isdayoff.NewCacheProvider(cache, isdayoff.WithDefaultParams(isdayoff.Params{CountryCode: &kz, SixDayWeek: &sd}))
+	info, err = isdayoff.Classify(ctx, p, date(2024, time.July, 13), isdayoff.Params{})
+	if err != nil || info.Kind != isdayoff.DayKindRegularWorkday {
+		t.Errorf("Classify(2024-07-13) with six-day week = %+v, %v; expected regular workday", info, err)
+	}
+	cal, err = isdayoff.FetchCalendar(ctx, p, 2024, isdayoff.Params{})
+	if err != nil {
+		t.Fatalf("FetchCalendar() failed: %v", err)
+	}
+	if !cal.SixDayWeek {
+		t.Error("FetchCalendar().SixDayWeek = false, expected six-day week calendar")
+	}
+}
@@ -0,0 +1,109 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RangeError is returned by GetRange when a chunk of the range could not be fetched
+type RangeError struct {
+	From time.Time // первый день неудавшегося фрагмента
+	To   time.Time // последний день неудавшегося фрагмента
+	Days []DayType // дни, полученные до неудавшегося фрагмента
+	Err  error
+}
+
+func (e *RangeError) Error() string {
+	return fmt.Sprintf("failed to get days from %s to %s: %v", e.From.Format(time.DateOnly), e.To.Format(time.DateOnly), e.Err)
+}
+
+func (e *RangeError) Unwrap() error {
+	return e.Err
+}
+
+// WithRangeConcurrency sets how many chunks of GetRange are requested at once. Default is 1.
+func WithRangeConcurrency(n int) Option {
+	return func(c *Client) {
+		c.rangeConcurrency = max(n, 1)
+	}
+}
+
+type chunk struct {
+	from, to time.Time
+	days     []DayType
+	err      error
+}
+
+// splitRange splits the range into chunks not longer than the API allows
+func splitRange(from, to time.Time) []*chunk {
+	var chunks []*chunk
+	for start := from; !start.After(to); start = start.AddDate(0, 0, maxPeriodDays) {
+		chunks = append(chunks, &chunk{from: start, to: earlier(start.AddDate(0, 0, maxPeriodDays-1), to)})
+	}
+	return chunks
+}
+
+// GetRange gets data from one date to another inclusive. Unlike GetByPeriod the range
+// may be of any length: it is split into requests of at most 366 days, which are made
+// concurrently according to WithRangeConcurrency, and the results are joined in order.
+// If a chunk fails, RangeError identifies it and holds the days fetched before it.
+func (c *Client) GetRange(from, to time.Time, params Params) ([]DayType, error) {
+	return c.GetRangeContext(context.Background(), from, to, params)
+}
+
+// GetRangeContext gets data for a range of any length using the provided context
+func (c *Client) GetRangeContext(ctx context.Context, from, to time.Time, params Params) ([]DayType, error) {
+	return getRange(ctx, c, from, to, params, c.rangeConcurrency)
+}
+
+// GetRange gets data for a range of any length using the provider, requesting
+// chunks one after another, see Client.GetRange
+func GetRange(ctx context.Context, p Provider, from, to time.Time, params Params) ([]DayType, error) {
+	return getRange(ctx, p, from, to, params, 1)
+}
+
+// getRange requests chunks of the range with the given concurrency
+func getRange(ctx context.Context, p Provider, from, to time.Time, params Params, concurrency int) ([]DayType, error) {
+	from, to = dateOf(from), dateOf(to)
+	params = resolveParams(p, params)
+	if err := validateOrder(from, to); err != nil {
+		return nil, err
+	}
+	if err := params.validate(false); err != nil {
+		return nil, err
+	}
+
+	chunks := splitRange(from, to)
+	sem := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	for _, ch := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			ch.days, ch.err = p.GetByPeriodContext(ctx, ch.from.Format(periodLayout), ch.to.Format(periodLayout), params)
+		}()
+	}
+	wg.Wait()
+
+	var stale error
+	result := make([]DayType, 0, daysBetween(from, to)+1)
+	for _, ch := range chunks {
+		if ch.err != nil && !(errors.Is(ch.err, ErrStale) && ch.days != nil) {
+			return nil, &RangeError{From: ch.from, To: ch.to, Days: result, Err: ch.err}
+		}
+		if ch.err != nil {
+			stale = ch.err
+		}
+		if len(ch.days) != daysBetween(ch.from, ch.to)+1 {
+			err := fmt.Errorf("unexpected number of days: %d", len(ch.days))
+			return nil, &RangeError{From: ch.from, To: ch.to, Days: result, Err: err}
+		}
+		result = append(result, ch.days...)
+	}
+	return result, stale
+}
@@ -0,0 +1,126 @@
+package isdayoff
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetRange(t *testing.T) {
+	var (
+		mu          sync.Mutex
+		inFlight    int
+		maxInFlight int
+	)
+	track := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			mu.Lock()
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			defer func() {
+				mu.Lock()
+				inFlight--
+				mu.Unlock()
+			}()
+			time.Sleep(5 * time.Millisecond)
+			return next.RoundTrip(req)
+		})
+	}
+
+	api, client := newFakeAPI(t, WithRangeConcurrency(3), WithMiddleware(track))
+	api.holidays["20210101"] = true
+
+	var expected []DayType
+	for year := 2019; year <= 2024; year++ {
+		days, err := client.GetBy(Params{Year: year})
+		if err != nil {
+			t.Fatalf("GetBy(%d) failed: %v", year, err)
+		}
+		expected = append(expected, days...)
+	}
+	requests := api.requestCount()
+
+	days, err := client.GetRange(date(2019, time.January, 1), date(2024, time.December, 31), Params{})
+	if err != nil {
+		t.Fatalf("GetRange() failed: %v", err)
+	}
+	if !slices.Equal(days, expected) {
+		t.Errorf("GetRange() returned %d days differing from yearly data of %d days", len(days), len(expected))
+	}
+	if got := api.requestCount() - requests; got != 6 {
+		t.Errorf("GetRange() made %d requests, expected 6 chunks of at most 366 days", got)
+	}
+	if maxInFlight > 3 {
+		t.Errorf("GetRange() made %d concurrent requests, expected at most 3", maxInFlight)
+	}
+
+	single, err := client.GetRange(date(2024, time.March, 1), date(2024, time.March, 1), Params{})
+	if err != nil || len(single) != 1 {
+		t.Errorf("GetRange() for a single day = %v, %v", single, err)
+	}
+	if _, err := client.GetRange(date(2024, time.March, 2), date(2024, time.March, 1), Params{}); err == nil {
+		t.Error("GetRange() with reversed dates should fail")
+	}
+}
+
+func TestSplitRange(t *testing.T) {
+	tests := []struct {
+		from, to time.Time
+		chunks   int
+	}{
+		{date(2024, time.January, 1), date(2024, time.December, 31), 1},
+		{date(2023, time.January, 1), date(2024, time.January, 1), 1},
+		{date(2023, time.January, 1), date(2024, time.January, 2), 2},
+		{date(2000, time.January, 1), date(2099, time.December, 31), 100},
+	}
+	for _, tt := range tests {
+		chunks := splitRange(tt.from, tt.to)
+		if len(chunks) != tt.chunks {
+			t.Errorf("splitRange(%v, %v) = %d chunks, expected %d", tt.from, tt.to, len(chunks), tt.chunks)
+			continue
+		}
+		next := tt.from
+		for _, ch := range chunks {
+			if !ch.from.Equal(next) || daysBetween(ch.from, ch.to) >= maxPeriodDays {
+				t.Errorf("splitRange(%v, %v) produced invalid chunk %v - %v", tt.from, tt.to, ch.from, ch.to)
+			}
+			next = ch.to.AddDate(0, 0, 1)
+		}
+		if !chunks[len(chunks)-1].to.Equal(tt.to) {
+			t.Errorf("splitRange(%v, %v) ends at %v", tt.from, tt.to, chunks[len(chunks)-1].to)
+		}
+	}
+}
+
+func TestGetRangePartialError(t *testing.T) {
+	api := &fakeAPI{holidays: map[string]bool{}, codes: map[string]byte{}}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// третий фрагмент начинается 2021-01-02
+		if r.URL.Query().Get("date1") == "20210102" {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("199"))
+			return
+		}
+		api.ServeHTTP(w, r)
+	}), WithRangeConcurrency(4))
+
+	_, err := client.GetRange(date(2019, time.January, 1), date(2022, time.December, 31), Params{})
+	var rangeErr *RangeError
+	if !errors.As(err, &rangeErr) {
+		t.Fatalf("GetRange() error = %v, expected RangeError", err)
+	}
+	if !rangeErr.From.Equal(date(2021, time.January, 2)) || !rangeErr.To.Equal(date(2022, time.January, 2)) {
+		t.Errorf("RangeError identifies chunk %v - %v", rangeErr.From, rangeErr.To)
+	}
+	if len(rangeErr.Days) != 2*366 {
+		t.Errorf("RangeError holds %d days, expected the two chunks before the failed one", len(rangeErr.Days))
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeInternalError {
+		t.Errorf("RangeError does not wrap API error: %v", err)
+	}
+}
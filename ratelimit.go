@@ -0,0 +1,95 @@
+package isdayoff
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// RateLimitError is returned by a fail-fast rate limiter when no request can be made right now
+type RateLimitError struct {
+	RetryAfter time.Duration // время до появления свободного токена
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("rate limit exceeded, retry after %v", e.RetryAfter)
+}
+
+// RateLimit configures client-side token bucket limiter
+type RateLimit struct {
+	RequestsPerSecond float64 // скорость пополнения токенов
+	Burst             int     // максимальное число запросов подряд без ожидания
+	FailFast          bool    // возвращать RateLimitError вместо ожидания токена
+}
+
+// WithRateLimit limits requests to the API made by all client methods.
+// Every attempt, including retries, consumes a token.
+// A non-positive RequestsPerSecond disables the limit.
+func WithRateLimit(limit RateLimit) Option {
+	return func(c *Client) {
+		if limit.RequestsPerSecond <= 0 {
+			c.limit = nil
+			return
+		}
+		c.limit = &limit
+	}
+}
+
+// limiter is a token bucket shared by all client methods
+type limiter struct {
+	mu       sync.Mutex
+	clock    Clock
+	rate     float64
+	burst    float64
+	failFast bool
+	tokens   float64
+	last     time.Time
+}
+
+func newLimiter(limit RateLimit, clock Clock) *limiter {
+	burst := float64(max(limit.Burst, 1))
+	return &limiter{
+		clock:    clock,
+		rate:     limit.RequestsPerSecond,
+		burst:    burst,
+		failFast: limit.FailFast,
+		tokens:   burst,
+		last:     clock.Now(),
+	}
+}
+
+// reserve takes a token if available, otherwise returns time until the next one
+func (l *limiter) reserve() time.Duration {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	if elapsed := now.Sub(l.last); elapsed > 0 {
+		l.tokens = min(l.burst, l.tokens+elapsed.Seconds()*l.rate)
+		l.last = now
+	}
+	if l.tokens >= 1 {
+		l.tokens--
+		return 0
+	}
+	return time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+}
+
+// wait blocks until a token is available or ctx is done
+func (l *limiter) wait(ctx context.Context) error {
+	for {
+		d := l.reserve()
+		if d == 0 {
+			return nil
+		}
+		if l.failFast {
+			return &RateLimitError{RetryAfter: d}
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-l.clock.After(d):
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeClock — управляемые часы для детерминированных тестов
+type fakeClock struct {
+	mu      sync.Mutex
+	now     time.Time
+	waiters []fakeWaiter
+}
+
+type fakeWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock(now time.Time) *fakeClock {
+	return &fakeClock{now: now}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, fakeWaiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires expired timers
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, w := range c.waiters {
+		if w.deadline.After(c.now) {
+			pending = append(pending, w)
+			continue
+		}
+		w.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// waitForWaiters blocks until n timers are pending
+func (c *fakeClock) waitForWaiters(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		c.mu.Lock()
+		got := len(c.waiters)
+		c.mu.Unlock()
+		if got >= n {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatalf("timed out waiting for %d pending timers", n)
+}
+
+func countingHandler(calls *atomic.Int32) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte("0"))
+	})
+}
+
+func TestRateLimitFailFast(t *testing.T) {
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	client := newTestClient(t, countingHandler(&calls),
+		WithClock(clock),
+		WithRateLimit(RateLimit{RequestsPerSecond: 2, Burst: 3, FailFast: true}),
+	)
+
+	// все методы расходуют общий бюджет токенов
+	if _, err := client.IsLeap(2024); err != nil {
+		t.Fatalf("IsLeap() failed: %v", err)
+	}
+	if _, err := client.Today(Params{}); err != nil {
+		t.Fatalf("Today() failed: %v", err)
+	}
+	if _, err := client.Tomorrow(Params{}); err != nil {
+		t.Fatalf("Tomorrow() failed: %v", err)
+	}
+
+	_, err := client.GetBy(Params{Year: 2024})
+	var limitErr *RateLimitError
+	if !errors.As(err, &limitErr) {
+		t.Fatalf("GetBy() error = %v, expected RateLimitError", err)
+	}
+	if limitErr.RetryAfter != 500*time.Millisecond {
+		t.Errorf("RetryAfter = %v, expected 500ms", limitErr.RetryAfter)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("server got %d requests, expected 3", calls.Load())
+	}
+
+	clock.Advance(500 * time.Millisecond)
+	if _, err := client.GetBy(Params{Year: 2024}); err != nil {
+		t.Fatalf("GetBy() after refill failed: %v", err)
+	}
+
+	// бакет не накапливает больше Burst токенов
+	clock.Advance(time.Hour)
+	for i := range 3 {
+		if _, err := client.IsLeap(2024); err != nil {
+			t.Fatalf("IsLeap() #%d after long pause failed: %v", i, err)
+		}
+	}
+	if _, err := client.IsLeap(2024); !errors.As(err, &limitErr) {
+		t.Errorf("IsLeap() error = %v, expected RateLimitError after burst", err)
+	}
+}
+
+func TestRateLimitBlocking(t *testing.T) {
+	var calls atomic.Int32
+	clock := newFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	client := newTestClient(t, countingHandler(&calls),
+		WithClock(clock),
+		WithRateLimit(RateLimit{RequestsPerSecond: 1, Burst: 1}),
+	)
+
+	if _, err := client.Today(Params{}); err != nil {
+		t.Fatalf("Today() failed: %v", err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.Tomorrow(Params{})
+		done <- err
+	}()
+
+	clock.waitForWaiters(t, 1)
+	select {
+	case err := <-done:
+		t.Fatalf("Tomorrow() returned before token was available: %v", err)
+	default:
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("server got %d requests while limiter should block, expected 1", calls.Load())
+	}
+
+	clock.Advance(time.Second)
+	if err := <-done; err != nil {
+		t.Fatalf("Tomorrow() failed: %v", err)
+	}
+	if calls.Load() != 2 {
+		t.Errorf("server got %d requests, expected 2", calls.Load())
+	}
+}
+
+func TestRateLimitContextCancel(t *testing.T) {
+	clock := newFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+	var calls atomic.Int32
+	client := newTestClient(t, countingHandler(&calls),
+		WithClock(clock),
+		WithRateLimit(RateLimit{RequestsPerSecond: 0.1, Burst: 1}),
+	)
+	if _, err := client.IsLeap(2024); err != nil {
+		t.Fatalf("IsLeap() failed: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := client.IsLeapContext(ctx, 2024)
+		done <- err
+	}()
+	clock.waitForWaiters(t, 1)
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("IsLeapContext() error = %v, expected context.Canceled", err)
+	}
+}
+
+func TestRateLimitNonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1} {
+		var calls atomic.Int32
+		clock := newFakeClock(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC))
+		client := newTestClient(t, countingHandler(&calls),
+			WithClock(clock),
+			WithRateLimit(RateLimit{RequestsPerSecond: 2, Burst: 1, FailFast: true}),
+			WithRateLimit(RateLimit{RequestsPerSecond: rate, Burst: 1, FailFast: true}),
+		)
+
+		// неположительная скорость отключает ограничение, а не блокирует навсегда
+		for i := range 3 {
+			if _, err := client.IsLeap(2024); err != nil {
+				t.Fatalf("rate %v: IsLeap() #%d failed: %v", rate, i, err)
+			}
+		}
+		if calls.Load() != 3 {
+			t.Errorf("rate %v: server got %d requests, expected 3", rate, calls.Load())
+		}
+	}
+}
@@ -0,0 +1,118 @@
+package isdayoff
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+)
+
+// Middleware wraps the transport used for API requests. It can add headers,
+// log, collect metrics or alter requests and responses in any other way.
+type Middleware func(http.RoundTripper) http.RoundTripper
+
+// RoundTripperFunc is an adapter to allow the use of ordinary functions as http.RoundTripper
+type RoundTripperFunc func(*http.Request) (*http.Response, error)
+
+// RoundTrip calls f(req)
+func (f RoundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// WithMiddleware adds middleware to the request pipeline.
+// The first middleware is the outermost one and sees the request first.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) {
+		c.middleware = append(c.middleware, mw...)
+	}
+}
+
+// chainClient returns a copy of client whose transport is wrapped by mw
+func chainClient(client *http.Client, mw []Middleware) *http.Client {
+	if len(mw) == 0 {
+		return client
+	}
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	for i := len(mw) - 1; i >= 0; i-- {
+		transport = mw[i](transport)
+	}
+	chained := *client
+	chained.Transport = transport
+	return &chained
+}
+
+// get performs GET request to the API endpoint and returns response body.
+// Every endpoint goes through this method. Identical concurrent requests
+// are coalesced into one.
+func (c *Client) get(ctx context.Context, path string, q url.Values) ([]byte, error) {
+	if c.offline != nil {
+		return nil, fmt.Errorf("request to %s: %w", path, c.offline)
+	}
+	u, err := url.Parse(c.baseURL + path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse base URL: %w", err)
+	}
+	u.RawQuery = q.Encode()
+
+	return c.flights.do(ctx, u.String(), func(ctx context.Context) ([]byte, error) {
+		return c.getWithRetry(ctx, u.String())
+	})
+}
+
+// getWithRetry performs request repeating it according to the retry policy
+func (c *Client) getWithRetry(ctx context.Context, u string) ([]byte, error) {
+	for attempt := 1; ; attempt++ {
+		body, res, err := c.attempt(ctx, u)
+		if err == nil {
+			return body, nil
+		}
+		if c.retry == nil || attempt >= c.retry.MaxAttempts || !c.retry.retryable(ctx, res, err) {
+			return nil, err
+		}
+		if err := c.sleep(ctx, c.retry.delay(attempt, res, c.clock.Now())); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// attempt performs a single request. Response is returned along with error
+// when the server answered, so that the caller can decide on retrying.
+func (c *Client) attempt(ctx context.Context, u string) ([]byte, *http.Response, error) {
+	if c.limiter != nil {
+		if err := c.limiter.wait(ctx); err != nil {
+			return nil, nil, err
+		}
+	}
+	if c.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.timeout)
+		defer cancel()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, nil, fmt.Errorf("http.NewRequestWithContext failed: %w", err)
+	}
+	req.Header.Set("User-Agent", c.userAgent)
+
+	res, err := c.doer.Do(req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("client.Do(req) failed: %w", err)
+	}
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, fmt.Errorf("io.ReadAll failed: %w", err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, res, parseAPIError(res.StatusCode, body)
+	}
+
+	return body, res, nil
+}
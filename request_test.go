@@ -0,0 +1,79 @@
+package isdayoff
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMiddlewareChain(t *testing.T) {
+	var (
+		mu     sync.Mutex
+		order  []string
+		paths  []string
+		agents []string
+	)
+	record := func(name string) Middleware {
+		return func(next http.RoundTripper) http.RoundTripper {
+			return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+				mu.Lock()
+				order = append(order, name)
+				mu.Unlock()
+				return next.RoundTrip(req)
+			})
+		}
+	}
+	header := func(next http.RoundTripper) http.RoundTripper {
+		return RoundTripperFunc(func(req *http.Request) (*http.Response, error) {
+			req.Header.Set("X-Request-Source", "middleware")
+			return next.RoundTrip(req)
+		})
+	}
+
+	httpClient := &http.Client{}
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path+"?"+r.URL.RawQuery)
+		agents = append(agents, r.UserAgent())
+		mu.Unlock()
+		if r.Header.Get("X-Request-Source") != "middleware" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Write([]byte("0"))
+	}), WithHTTPClient(httpClient), WithMiddleware(record("outer"), record("inner")), WithMiddleware(header))
+
+	if httpClient.Transport != nil {
+		t.Error("WithMiddleware() modified the provided http client")
+	}
+
+	tz := "Asia/Almaty"
+	if _, err := client.IsLeap(2024); err != nil {
+		t.Fatalf("IsLeap() failed: %v", err)
+	}
+	if _, err := client.GetBy(Params{Year: 2024}); err != nil {
+		t.Fatalf("GetBy() failed: %v", err)
+	}
+	if _, err := client.GetByPeriod("20240101", "20240101", Params{TZ: &tz}); err != nil {
+		t.Fatalf("GetByPeriod() failed: %v", err)
+	}
+	if _, err := client.Today(Params{}); err != nil {
+		t.Fatalf("Today() failed: %v", err)
+	}
+	if _, err := client.Tomorrow(Params{}); err != nil {
+		t.Fatalf("Tomorrow() failed: %v", err)
+	}
+
+	if got, expected := strings.Join(order, ","), strings.TrimSuffix(strings.Repeat("outer,inner,", 5), ","); got != expected {
+		t.Errorf("middleware order = %s, expected %s", got, expected)
+	}
+	if !strings.Contains(paths[2], "tz=Asia%2FAlmaty") {
+		t.Errorf("GetByPeriod() ignored Params.TZ: %s", paths[2])
+	}
+	for i, agent := range agents {
+		if agent != defaultUserAgent {
+			t.Errorf("request %s sent User-Agent %q, expected %q", paths[i], agent, defaultUserAgent)
+		}
+	}
+}
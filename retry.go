@@ -0,0 +1,118 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy describes how requests failed with transient errors are retried.
+// Errors ErrorCodeWrongDate and ErrorCodeNotFound are never retried.
+type RetryPolicy struct {
+	MaxAttempts int           // общее число попыток, включая первую
+	BaseDelay   time.Duration // задержка перед второй попыткой, далее удваивается
+	MaxDelay    time.Duration // верхняя граница задержки
+	Jitter      float64       // доля случайного уменьшения задержки, от 0 до 1
+
+	// RetryableStatus reports whether response with given HTTP status should be retried.
+	// If nil, 429 and 5xx statuses are retried.
+	RetryableStatus func(status int) bool
+}
+
+// DefaultRetryPolicy returns policy with 3 attempts and exponential backoff from 200ms up to 5s
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    5 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+// WithRetry enables retries of transient failures: connection errors,
+// ErrorCodeInternalError and statuses accepted by policy.RetryableStatus.
+// Retry-After header of the response is honoured when it asks to wait longer than the backoff.
+func WithRetry(policy RetryPolicy) Option {
+	return func(c *Client) {
+		c.retry = &policy
+	}
+}
+
+func defaultRetryableStatus(status int) bool {
+	return status == http.StatusTooManyRequests || status >= http.StatusInternalServerError
+}
+
+// retryable reports whether the attempt finished with res and err should be repeated
+func (p *RetryPolicy) retryable(ctx context.Context, res *http.Response, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var limitErr *RateLimitError
+	if errors.As(err, &limitErr) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Code {
+		case ErrorCodeWrongDate, ErrorCodeNotFound:
+			return false
+		case ErrorCodeInternalError:
+			return true
+		}
+	}
+	if res == nil {
+		// соединение не установлено или оборвалось
+		return true
+	}
+	if p.RetryableStatus != nil {
+		return p.RetryableStatus(res.StatusCode)
+	}
+	return defaultRetryableStatus(res.StatusCode)
+}
+
+// delay returns pause before the attempt following the given one (counted from 1)
+func (p *RetryPolicy) delay(attempt int, res *http.Response, now time.Time) time.Duration {
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay <= 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay > 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d -= time.Duration(float64(d) * min(p.Jitter, 1) * rand.Float64())
+	}
+	if res != nil {
+		if after := retryAfter(res.Header.Get("Retry-After"), now); after > d {
+			d = after
+		}
+	}
+	return d
+}
+
+// retryAfter parses Retry-After header given either in seconds or as HTTP date
+func retryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(max(seconds, 0)) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return max(t.Sub(now), 0)
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-c.clock.After(d):
+		return nil
+	}
+}
@@ -0,0 +1,154 @@
+package isdayoff
+
+import (
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// flakyHandler отвечает ошибкой failures раз, затем отдаёт body
+func flakyHandler(calls *atomic.Int32, failures int32, fail func(w http.ResponseWriter), body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) <= failures {
+			fail(w)
+			return
+		}
+		w.Write([]byte(body))
+	})
+}
+
+func fastRetry(attempts int) Option {
+	return WithRetry(RetryPolicy{MaxAttempts: attempts, BaseDelay: time.Millisecond, MaxDelay: 5 * time.Millisecond, Jitter: 0.5})
+}
+
+func TestRetryTransientFailures(t *testing.T) {
+	tests := []struct {
+		name string
+		fail func(w http.ResponseWriter)
+	}{
+		{"internal error code", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte("199"))
+		}},
+		{"bad gateway", func(w http.ResponseWriter) {
+			w.WriteHeader(http.StatusBadGateway)
+		}},
+		{"connection dropped", func(w http.ResponseWriter) {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := newTestClient(t, flakyHandler(&calls, 2, tt.fail, "0101"), fastRetry(3))
+
+			days, err := client.GetBy(Params{Year: 2024})
+			if err != nil {
+				t.Fatalf("GetBy() failed after retries: %v", err)
+			}
+			if len(days) != 4 {
+				t.Errorf("GetBy() returned %d days, expected 4", len(days))
+			}
+			if calls.Load() != 3 {
+				t.Errorf("server got %d requests, expected 3", calls.Load())
+			}
+		})
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, flakyHandler(&calls, 10, func(w http.ResponseWriter) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("199"))
+	}, "0"), fastRetry(3))
+
+	_, err := client.Today(Params{})
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != ErrorCodeInternalError {
+		t.Fatalf("Today() error = %v, expected ErrorCodeInternalError", err)
+	}
+	if calls.Load() != 3 {
+		t.Errorf("server got %d requests, expected 3", calls.Load())
+	}
+}
+
+func TestRetryNotRetryable(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		opts   []Option
+	}{
+		{"wrong date", http.StatusBadRequest, "100", []Option{fastRetry(5)}},
+		{"not found", http.StatusNotFound, "101", []Option{fastRetry(5)}},
+		{"wrong date with 5xx status", http.StatusInternalServerError, "100", []Option{fastRetry(5)}},
+		{"status rejected by predicate", http.StatusServiceUnavailable, "", []Option{WithRetry(RetryPolicy{
+			MaxAttempts:     5,
+			RetryableStatus: func(status int) bool { return status == http.StatusBadGateway },
+		})}},
+		{"retries disabled", http.StatusInternalServerError, "199", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			client := newTestClient(t, flakyHandler(&calls, 10, func(w http.ResponseWriter) {
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}, "0"), tt.opts...)
+
+			if _, err := client.GetByPeriod("20240201", "20240301", Params{}); err == nil {
+				t.Fatal("GetByPeriod() expected error")
+			}
+			if calls.Load() != 1 {
+				t.Errorf("server got %d requests, expected 1", calls.Load())
+			}
+		})
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	policy := RetryPolicy{BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+	now := time.Date(2024, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+	for attempt, expected := range map[int]time.Duration{
+		1:  100 * time.Millisecond,
+		2:  200 * time.Millisecond,
+		3:  400 * time.Millisecond,
+		4:  800 * time.Millisecond,
+		5:  time.Second,
+		50: time.Second,
+	} {
+		if d := policy.delay(attempt, nil, now); d != expected {
+			t.Errorf("delay(%d) = %v, expected %v", attempt, d, expected)
+		}
+	}
+
+	policy.Jitter = 0.5
+	for range 100 {
+		if d := policy.delay(2, nil, now); d < 100*time.Millisecond || d > 200*time.Millisecond {
+			t.Fatalf("delay with jitter = %v, expected within [100ms, 200ms]", d)
+		}
+	}
+
+	res := &http.Response{Header: http.Header{}}
+	res.Header.Set("Retry-After", "3")
+	if d := policy.delay(1, res, now); d != 3*time.Second {
+		t.Errorf("delay with Retry-After in seconds = %v, expected 3s", d)
+	}
+	res.Header.Set("Retry-After", now.Add(7*time.Second).Format(http.TimeFormat))
+	if d := policy.delay(1, res, now); d != 7*time.Second {
+		t.Errorf("delay with Retry-After date = %v, expected 7s", d)
+	}
+	res.Header.Set("Retry-After", "soon")
+	if d := policy.delay(1, res, now); d > 100*time.Millisecond {
+		t.Errorf("delay with malformed Retry-After = %v, expected backoff", d)
+	}
+}
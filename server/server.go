@@ -0,0 +1,269 @@
+// Package server serves production calendars over HTTP with the same API as isdayoff.ru,
+// so that services in other languages can use an internal endpoint instead.
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+)
+
+// ErrNotFound is returned by sources that have no data for the year.
+// The server answers such requests with error 101.
+var ErrNotFound = errors.New("year calendar not found")
+
+// Source provides year calendars the server answers from
+type Source interface {
+	Year(ctx context.Context, key isdayoff.YearKey) ([]isdayoff.DayType, error)
+}
+
+// SourceFunc adapts a function to Source
+type SourceFunc func(ctx context.Context, key isdayoff.YearKey) ([]isdayoff.DayType, error)
+
+// Year calls f(ctx, key)
+func (f SourceFunc) Year(ctx context.Context, key isdayoff.YearKey) ([]isdayoff.DayType, error) {
+	return f(ctx, key)
+}
+
+// ClientSource fetches years with the client, so the server proxies isdayoff.ru.
+// Configure the client with WithCache to avoid a request per incoming request.
+// Error 101 of the upstream API is reported as ErrNotFound.
+func ClientSource(client *isdayoff.Client) Source {
+	return SourceFunc(func(ctx context.Context, key isdayoff.YearKey) ([]isdayoff.DayType, error) {
+		cc := key.CountryCode
+		days, err := client.GetByContext(ctx, isdayoff.Params{
+			Year:        key.Year,
+			CountryCode: &cc,
+			Pre:         &key.Pre,
+			Covid:       &key.Covid,
+			SixDayWeek:  &key.SixDayWeek,
+		})
+		var apiErr *isdayoff.APIError
+		if errors.As(err, &apiErr) && apiErr.Code == isdayoff.ErrorCodeNotFound {
+			return nil, fmt.Errorf("%w: %v", ErrNotFound, err)
+		}
+		if days == nil {
+			return nil, err
+		}
+		// устаревшие данные из кэша лучше ошибки
+		return days, nil
+	})
+}
+
+// CacheSource answers from a local data store, e.g. a FileCache filled in advance.
+// Missing years are reported as ErrNotFound.
+func CacheSource(cache isdayoff.Cache) Source {
+	return SourceFunc(func(ctx context.Context, key isdayoff.YearKey) ([]isdayoff.DayType, error) {
+		days, ok, err := cache.Get(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, ErrNotFound
+		}
+		return days, nil
+	})
+}
+
+// Option configures the server
+type Option func(*Server)
+
+// WithNow sets the function returning current time for /today and /tomorrow
+func WithNow(now func() time.Time) Option {
+	return func(s *Server) {
+		if now != nil {
+			s.now = now
+		}
+	}
+}
+
+// Server is an http.Handler emulating isdayoff.ru API:
+// /api/getdata, /api/isleap, /today and /tomorrow
+type Server struct {
+	src Source
+	now func() time.Time
+	mux *http.ServeMux
+}
+
+// New creates server answering from src
+func New(src Source, opts ...Option) *Server {
+	s := &Server{src: src, now: time.Now, mux: http.NewServeMux()}
+	for _, opt := range opts {
+		opt(s)
+	}
+	s.mux.HandleFunc("/api/getdata", s.getData)
+	s.mux.HandleFunc("/api/isleap", s.isLeap)
+	s.mux.HandleFunc("/today", s.alias(0))
+	s.mux.HandleFunc("/tomorrow", s.alias(1))
+	return s
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mux.ServeHTTP(w, r)
+}
+
+// apiError is an error answered with one of the API error codes
+type apiError struct {
+	code   isdayoff.ErrorCode
+	status int
+}
+
+var (
+	errWrongDate = &apiError{isdayoff.ErrorCodeWrongDate, http.StatusBadRequest}
+	errNotFound  = &apiError{isdayoff.ErrorCodeNotFound, http.StatusNotFound}
+	errInternal  = &apiError{isdayoff.ErrorCodeInternalError, http.StatusInternalServerError}
+)
+
+func (e *apiError) Error() string {
+	return "API error " + string(e.code)
+}
+
+// write sends the response body or the error code
+func write(w http.ResponseWriter, body string, err error) {
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	if err != nil {
+		var e *apiError
+		switch {
+		case errors.As(err, &e):
+		case errors.Is(err, ErrNotFound):
+			e = errNotFound
+		default:
+			e = errInternal
+		}
+		w.WriteHeader(e.status)
+		w.Write([]byte(e.code))
+		return
+	}
+	w.Write([]byte(body))
+}
+
+// key reads the year calendar filters from the query
+func key(q url.Values, year int) (isdayoff.YearKey, error) {
+	k := isdayoff.YearKey{Year: year, CountryCode: isdayoff.CountryCodeRussia}
+	if cc := q.Get("cc"); cc != "" {
+		k.CountryCode = isdayoff.CountryCode(strings.ToLower(cc))
+	}
+	if !k.CountryCode.Valid() {
+		return k, errNotFound
+	}
+	k.Pre = q.Get("pre") == "1"
+	k.Covid = q.Get("covid") == "1"
+	k.SixDayWeek = q.Get("sd") == "1"
+	return k, nil
+}
+
+// days returns types of days from one date to another inclusive
+func (s *Server) days(ctx context.Context, q url.Values, from, to time.Time) (string, error) {
+	var b strings.Builder
+	for year := from.Year(); year <= to.Year(); year++ {
+		k, err := key(q, year)
+		if err != nil {
+			return "", err
+		}
+		days, err := s.src.Year(ctx, k)
+		if err != nil {
+			return "", err
+		}
+		if len(days) != time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() {
+			return "", fmt.Errorf("unexpected number of days for year %d: %d", year, len(days))
+		}
+		start, end := 0, len(days)
+		if year == from.Year() {
+			start = from.YearDay() - 1
+		}
+		if year == to.Year() {
+			end = to.YearDay()
+		}
+		for _, day := range days[start:end] {
+			b.WriteString(string(day))
+		}
+	}
+	return b.String(), nil
+}
+
+// getData serves /api/getdata by year, month, day or period
+func (s *Server) getData(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	from, to, err := period(q)
+	if err != nil {
+		write(w, "", err)
+		return
+	}
+	body, err := s.days(r.Context(), q, from, to)
+	write(w, body, err)
+}
+
+// period parses the requested dates: date1 and date2, or year with optional month and day
+func period(q url.Values) (from, to time.Time, err error) {
+	if q.Has("date1") || q.Has("date2") {
+		var err1, err2 error
+		from, err1 = time.Parse("20060102", q.Get("date1"))
+		to, err2 = time.Parse("20060102", q.Get("date2"))
+		if err1 != nil || err2 != nil || to.Before(from) || to.Sub(from) >= 366*24*time.Hour {
+			return from, to, errWrongDate
+		}
+		return from, to, nil
+	}
+
+	year, err := strconv.Atoi(q.Get("year"))
+	if err != nil || year < 1 || year > 9999 {
+		return from, to, errWrongDate
+	}
+	from = time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to = from.AddDate(1, 0, -1)
+	if q.Get("month") != "" {
+		month, err := strconv.Atoi(q.Get("month"))
+		if err != nil || month < 1 || month > 12 {
+			return from, to, errWrongDate
+		}
+		from = time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		to = from.AddDate(0, 1, -1)
+	}
+	if q.Get("day") != "" {
+		day, err := strconv.Atoi(q.Get("day"))
+		if err != nil || q.Get("month") == "" || day < 1 || day > to.Day() {
+			return from, to, errWrongDate
+		}
+		from = from.AddDate(0, 0, day-1)
+		to = from
+	}
+	return from, to, nil
+}
+
+// isLeap serves /api/isleap
+func (s *Server) isLeap(w http.ResponseWriter, r *http.Request) {
+	year, err := strconv.Atoi(r.URL.Query().Get("year"))
+	if err != nil || year < 1 || year > 9999 {
+		write(w, "", errWrongDate)
+		return
+	}
+	leap := time.Date(year, time.December, 31, 0, 0, 0, 0, time.UTC).YearDay() == 366
+	write(w, map[bool]string{false: string(isdayoff.YearTypeNotLeap), true: string(isdayoff.YearTypeLeap)}[leap], nil)
+}
+
+// alias serves /today and /tomorrow in the time zone of tz, Europe/Moscow by default
+func (s *Server) alias(offset int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		tz := q.Get("tz")
+		if tz == "" {
+			tz = "Europe/Moscow"
+		}
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			write(w, "", errWrongDate)
+			return
+		}
+		now := s.now().In(loc).AddDate(0, 0, offset)
+		date := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+		body, err := s.days(r.Context(), q, date, date)
+		write(w, body, err)
+	}
+}
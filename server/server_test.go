@@ -0,0 +1,167 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/kotopheiop/isdayoff"
+	"github.com/kotopheiop/isdayoff/server"
+)
+
+// fixture читает производственный календарь РФ 2024 года с сокращёнными днями
+func fixture(t *testing.T) []isdayoff.DayType {
+	t.Helper()
+	key := isdayoff.YearKey{Year: 2024, CountryCode: isdayoff.CountryCodeRussia, Pre: true}
+	days, ok, err := isdayoff.Embedded{}.Get(context.Background(), key)
+	if !ok || err != nil {
+		t.Fatalf("Embedded.Get(2024) = %v, %v", ok, err)
+	}
+	return days
+}
+
+// newServer запускает сервер с календарём РФ на 2024 год (pre=1) и годом 2025,
+// в котором все дни рабочие
+func newServer(t *testing.T, opts ...server.Option) (*httptest.Server, *isdayoff.Client) {
+	t.Helper()
+	ctx := context.Background()
+	store := isdayoff.NewMemoryCache(0, 0)
+	store.Set(ctx, isdayoff.YearKey{Year: 2024, CountryCode: isdayoff.CountryCodeRussia, Pre: true}, fixture(t))
+	store.Set(ctx, isdayoff.YearKey{Year: 2025, CountryCode: isdayoff.CountryCodeRussia, Pre: true}, slices.Repeat([]isdayoff.DayType{isdayoff.DayTypeWorking}, 365))
+
+	srv := httptest.NewServer(server.New(server.CacheSource(store), opts...))
+	t.Cleanup(srv.Close)
+	return srv, isdayoff.New(isdayoff.WithBaseURL(srv.URL))
+}
+
+func TestClientAgainstServer(t *testing.T) {
+	now := time.Date(2024, time.December, 30, 22, 0, 0, 0, time.UTC) // 31 декабря по Москве
+	_, client := newServer(t, server.WithNow(func() time.Time { return now }))
+	pre := true
+	params := isdayoff.Params{Pre: &pre}
+
+	year := params
+	year.Year = 2024
+	days, err := client.GetBy(year)
+	if err != nil || !slices.Equal(days, fixture(t)) {
+		t.Errorf("GetBy(2024) = %d days, %v", len(days), err)
+	}
+
+	month := time.November
+	day := 2
+	date := year
+	date.Month = &month
+	date.Day = &day
+	days, err = client.GetBy(date)
+	if err != nil || !slices.Equal(days, []isdayoff.DayType{isdayoff.DayTypeHalfHoliday}) {
+		t.Errorf("GetBy(2024-11-02) = %v, %v", days, err)
+	}
+
+	days, err = client.GetByPeriod("20241230", "20250102", params)
+	expected := []isdayoff.DayType{"1", "1", "0", "0"}
+	if err != nil || !slices.Equal(days, expected) {
+		t.Errorf("GetByPeriod() across years = %v, %v; expected %v", days, err, expected)
+	}
+
+	today, err := client.Today(params)
+	if err != nil || *today != isdayoff.DayTypeNonWorking {
+		t.Errorf("Today() = %v, %v", today, err)
+	}
+	utc := "UTC"
+	today, err = client.Today(isdayoff.Params{Pre: &pre, TZ: &utc})
+	if err != nil || *today != isdayoff.DayTypeNonWorking {
+		t.Errorf("Today() in UTC = %v, %v", today, err)
+	}
+	tomorrow, err := client.Tomorrow(params)
+	if err != nil || *tomorrow != isdayoff.DayTypeWorking {
+		t.Errorf("Tomorrow() = %v, %v", tomorrow, err)
+	}
+
+	for year, expected := range map[int]bool{2024: true, 2025: false, 1900: false, 2000: true} {
+		if leap, err := client.IsLeap(year); err != nil || leap != expected {
+			t.Errorf("IsLeap(%d) = %v, %v", year, leap, err)
+		}
+	}
+
+	// данных нет: без pre, другая страна, другой год
+	kz := isdayoff.CountryCodeKazakhstan
+	for _, p := range []isdayoff.Params{{Year: 2024}, {Year: 2024, Pre: &pre, CountryCode: &kz}, {Year: 2023, Pre: &pre}} {
+		var apiErr *isdayoff.APIError
+		if _, err := client.GetBy(p); !errors.As(err, &apiErr) || apiErr.Code != isdayoff.ErrorCodeNotFound || apiErr.Status != http.StatusNotFound {
+			t.Errorf("GetBy(%+v) error = %v, expected API error 101", p, err)
+		}
+	}
+}
+
+func TestRawResponses(t *testing.T) {
+	srv, _ := newServer(t)
+	tests := []struct {
+		query  string
+		status int
+		body   string
+	}{
+		{"/api/getdata?year=2024&month=05&day=09&pre=1", http.StatusOK, "1"},
+		{"/api/getdata?year=2024&month=5&day=8&pre=1", http.StatusOK, "2"},
+		{"/api/getdata?date1=20240427&date2=20240501&pre=1", http.StatusOK, "01111"},
+		{"/api/getdata?year=2024&month=13&pre=1", http.StatusBadRequest, "100"},
+		{"/api/getdata?year=2024&month=02&day=30&pre=1", http.StatusBadRequest, "100"},
+		{"/api/getdata?year=2024&day=1&pre=1", http.StatusBadRequest, "100"},
+		{"/api/getdata?year=abc", http.StatusBadRequest, "100"},
+		{"/api/getdata?date1=20240101&date2=20250101&pre=1", http.StatusBadRequest, "100"},
+		{"/api/getdata?date1=20240102&date2=20240101&pre=1", http.StatusBadRequest, "100"},
+		{"/api/getdata?year=2024&cc=xx", http.StatusNotFound, "101"},
+		{"/api/getdata?year=2024&cc=RU&pre=1&month=1&day=1", http.StatusOK, "1"},
+		{"/api/isleap?year=2024", http.StatusOK, "1"},
+		{"/api/isleap?year=2023", http.StatusOK, "0"},
+		{"/api/isleap?year=0", http.StatusBadRequest, "100"},
+		{"/today?tz=Mars/Olympus", http.StatusBadRequest, "100"},
+	}
+	for _, tt := range tests {
+		res, err := http.Get(srv.URL + tt.query)
+		if err != nil {
+			t.Fatalf("GET %s failed: %v", tt.query, err)
+		}
+		body, _ := io.ReadAll(res.Body)
+		res.Body.Close()
+		if res.StatusCode != tt.status || string(body) != tt.body {
+			t.Errorf("GET %s = %d %q, expected %d %q", tt.query, res.StatusCode, body, tt.status, tt.body)
+		}
+	}
+}
+
+func TestSourceErrors(t *testing.T) {
+	failing := server.SourceFunc(func(ctx context.Context, key isdayoff.YearKey) ([]isdayoff.DayType, error) {
+		return nil, errors.New("disk failure")
+	})
+	srv := httptest.NewServer(server.New(failing))
+	t.Cleanup(srv.Close)
+
+	_, err := isdayoff.New(isdayoff.WithBaseURL(srv.URL)).GetBy(isdayoff.Params{Year: 2024})
+	var apiErr *isdayoff.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != isdayoff.ErrorCodeInternalError || apiErr.Status != http.StatusInternalServerError {
+		t.Errorf("GetBy() error = %v, expected API error 199", err)
+	}
+}
+
+func TestClientSource(t *testing.T) {
+	// цепочка: клиент -> прокси на ClientSource -> сервер на CacheSource
+	upstream, _ := newServer(t)
+	proxy := httptest.NewServer(server.New(server.ClientSource(isdayoff.New(isdayoff.WithBaseURL(upstream.URL)))))
+	t.Cleanup(proxy.Close)
+	client := isdayoff.New(isdayoff.WithBaseURL(proxy.URL))
+
+	pre := true
+	days, err := client.GetByPeriod("20240427", "20240501", isdayoff.Params{Pre: &pre})
+	if err != nil || !slices.Equal(days, []isdayoff.DayType{"0", "1", "1", "1", "1"}) {
+		t.Errorf("GetByPeriod() through proxy = %v, %v", days, err)
+	}
+	var apiErr *isdayoff.APIError
+	if _, err := client.GetBy(isdayoff.Params{Year: 2023}); !errors.As(err, &apiErr) || apiErr.Code != isdayoff.ErrorCodeNotFound {
+		t.Errorf("GetBy(2023) through proxy error = %v, expected API error 101", err)
+	}
+}
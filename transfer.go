@@ -0,0 +1,107 @@
+package isdayoff
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// Transfer is a day off moved from a weekend day to a weekday
+type Transfer struct {
+	// From is the weekend day whose rest was moved: a working weekend day or
+	// a holiday that fell on a weekend. Zero if the weekday off has no pair.
+	From time.Time
+	// To is the weekday that became a day off. Zero if no such day was found.
+	To time.Time
+	// Holiday is set if From is a holiday of the catalogue
+	Holiday *Holiday
+}
+
+func (t Transfer) String() string {
+	day := func(d time.Time) string {
+		if d.IsZero() {
+			return "?"
+		}
+		return fmt.Sprintf("%s (%s)", d.Format(time.DateOnly), d.Weekday())
+	}
+	return day(t.From) + " -> " + day(t.To)
+}
+
+// DetectTransfers finds transfers of days off in days starting at start, e.g. a year
+// returned by GetBy or several consecutive years. Working weekend days and holidays
+// falling on a weekend are paired with weekdays off that are not holidays of the
+// catalogue. When several pairings are possible, the closest days are paired first,
+// so the pairs may differ from the decree while the sets of dates match it.
+// Days left without a pair are returned with zero From or To. Weekdays off missing
+// from the catalogue, e.g. observed US holidays or lunar feasts of years not in it,
+// are reported as transfers as well.
+// CountryCode and SixDayWeek of params should match the request.
+func DetectTransfers(start time.Time, days []DayType, params Params) []Transfer {
+	var sources, targets []DayInfo
+	for _, info := range Annotate(start, days, params) {
+		switch {
+		case info.Weekend && (info.Type.IsWorking() || info.Holiday != nil):
+			sources = append(sources, info)
+		case info.Kind == DayKindTransferredOff:
+			targets = append(targets, info)
+		}
+	}
+
+	type pair struct{ source, target int }
+	var pairs []pair
+	for i := range sources {
+		for j := range targets {
+			pairs = append(pairs, pair{i, j})
+		}
+	}
+	distance := func(p pair) int {
+		d := daysBetween(sources[p.source].Date, targets[p.target].Date)
+		return max(d, -d)
+	}
+	slices.SortStableFunc(pairs, func(a, b pair) int {
+		return cmp.Compare(distance(a), distance(b))
+	})
+
+	var result []Transfer
+	usedSource := make([]bool, len(sources))
+	usedTarget := make([]bool, len(targets))
+	for _, p := range pairs {
+		if usedSource[p.source] || usedTarget[p.target] {
+			continue
+		}
+		usedSource[p.source], usedTarget[p.target] = true, true
+		result = append(result, Transfer{From: sources[p.source].Date, To: targets[p.target].Date, Holiday: sources[p.source].Holiday})
+	}
+	for i, info := range sources {
+		// праздник в выходной без переноса не считается переносом
+		if !usedSource[i] && info.Type.IsWorking() {
+			result = append(result, Transfer{From: info.Date})
+		}
+	}
+	for j, info := range targets {
+		if !usedTarget[j] {
+			result = append(result, Transfer{To: info.Date})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Transfer) int {
+		return transferDate(a).Compare(transferDate(b))
+	})
+	return result
+}
+
+// transferDate returns the date transfers are ordered by: the weekday off, if any
+func transferDate(t Transfer) time.Time {
+	if t.To.IsZero() {
+		return t.From
+	}
+	return t.To
+}
+
+// Transfers finds transfers of days off within the calendar, see DetectTransfers
+func (cal *Calendar) Transfers() []Transfer {
+	cc := cal.CountryCode
+	sixDayWeek := cal.SixDayWeek
+	return DetectTransfers(cal.Start, cal.Days, Params{CountryCode: &cc, SixDayWeek: &sixDayWeek})
+}
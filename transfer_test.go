@@ -0,0 +1,96 @@
+package isdayoff
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestCalendarTransfers(t *testing.T) {
+	client := newTestClient(t, fixtureHandler(t))
+	cc := CountryCodeRussia
+	pre := true
+	params := Params{CountryCode: &cc, Pre: &pre}
+
+	// постановление о переносе выходных дней в 2024 году
+	cal, err := client.Calendar(2024, params)
+	if err != nil {
+		t.Fatalf("Calendar(2024) failed: %v", err)
+	}
+	transfers := cal.Transfers()
+	var from, to []time.Time
+	for _, tr := range transfers {
+		from = append(from, tr.From)
+		to = append(to, tr.To)
+	}
+	slices.SortFunc(from, time.Time.Compare)
+	expectedFrom := []time.Time{date(2024, time.January, 6), date(2024, time.January, 7), date(2024, time.April, 27), date(2024, time.November, 2), date(2024, time.December, 28)}
+	expectedTo := []time.Time{date(2024, time.April, 29), date(2024, time.April, 30), date(2024, time.May, 10), date(2024, time.December, 30), date(2024, time.December, 31)}
+	if !slices.EqualFunc(from, expectedFrom, time.Time.Equal) || !slices.EqualFunc(to, expectedTo, time.Time.Equal) {
+		t.Errorf("Transfers() = %v", transfers)
+	}
+	// однозначные пары: рабочая суббота и ближайший понедельник
+	for _, expected := range []Transfer{
+		{From: date(2024, time.April, 27), To: date(2024, time.April, 29)},
+		{From: date(2024, time.December, 28), To: date(2024, time.December, 30)},
+	} {
+		if !slices.ContainsFunc(transfers, func(tr Transfer) bool { return tr.From.Equal(expected.From) && tr.To.Equal(expected.To) }) {
+			t.Errorf("Transfers() does not contain %v: %v", expected, transfers)
+		}
+	}
+	for _, tr := range transfers {
+		if tr.From.Month() == time.January && (tr.Holiday == nil || tr.Holiday.NameEN != "New Year holidays" && tr.Holiday.NameEN != "Orthodox Christmas") {
+			t.Errorf("transfer %v should refer to a January holiday, got %+v", tr, tr.Holiday)
+		}
+	}
+
+	// в 2023 году праздник 4 ноября выпал на субботу и перенесён на понедельник
+	cal, err = client.Calendar(2023, params)
+	if err != nil {
+		t.Fatalf("Calendar(2023) failed: %v", err)
+	}
+	unity := Transfer{From: date(2023, time.November, 4), To: date(2023, time.November, 6)}
+	transfers = cal.Transfers()
+	if len(transfers) != 3 || !transfers[2].From.Equal(unity.From) || !transfers[2].To.Equal(unity.To) {
+		t.Errorf("Transfers() for 2023 = %v, expected 3 transfers ending with %v", transfers, unity)
+	}
+}
+
+func TestDetectTransfersAcrossYears(t *testing.T) {
+	// рабочая суббота 28 декабря 2024 года за выходной 2 января 2025 года,
+	// которого нет в каталоге КЗ, и внеплановый выходной без пары
+	start := date(2024, time.December, 28)
+	days := []DayType{
+		DayTypeWorking,    // 28 декабря, суббота
+		DayTypeNonWorking, // 29 декабря, воскресенье
+		DayTypeWorking,    // 30 декабря
+		DayTypeNonWorking, // 31 декабря
+		DayTypeNonWorking, // 1 января, праздник
+		DayTypeNonWorking, // 2 января, праздник
+		DayTypeNonWorking, // 3 января
+	}
+	cc := CountryCodeKazakhstan
+	transfers := DetectTransfers(start, days, Params{CountryCode: &cc})
+
+	expected := []Transfer{
+		{From: date(2024, time.December, 28), To: date(2024, time.December, 31)},
+		{To: date(2025, time.January, 3)},
+	}
+	if len(transfers) != len(expected) {
+		t.Fatalf("DetectTransfers() = %v, expected %v", transfers, expected)
+	}
+	for i, tr := range transfers {
+		if !tr.From.Equal(expected[i].From) || !tr.To.Equal(expected[i].To) {
+			t.Errorf("DetectTransfers()[%d] = %v, expected %v", i, tr, expected[i])
+		}
+	}
+	if got := transfers[1].String(); got != "? -> 2025-01-03 (Friday)" {
+		t.Errorf("String() = %q", got)
+	}
+
+	// при шестидневной неделе суббота обычный рабочий день
+	sd := true
+	if got := DetectTransfers(start, days[:2], Params{CountryCode: &cc, SixDayWeek: &sd}); len(got) != 0 {
+		t.Errorf("DetectTransfers() with six-day week = %v, expected none", got)
+	}
+}
@@ -0,0 +1,105 @@
+package isdayoff
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ValidationError reports invalid input detected locally, before any request is made.
+// It is distinct from APIError, which is returned by the API.
+type ValidationError struct {
+	Field  string // имя параметра, например "date1" или "Month"
+	Value  any    // переданное значение
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s %v: %s", e.Field, e.Value, e.Reason)
+}
+
+// ValidationErrors lists all problems found in Params. It unwraps to the individual
+// ValidationError values, so errors.As can be used to inspect the first one.
+type ValidationErrors []*ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+func (e ValidationErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, err := range e {
+		errs[i] = err
+	}
+	return errs
+}
+
+// Validate checks params for GetBy: Year is required, Month must be 1..12,
+// Day requires Month and must exist in that month, CountryCode must be known
+// and TZ must be an IANA time zone name. All problems are reported at once
+// as ValidationErrors.
+func (p Params) Validate() error {
+	return p.validate(true)
+}
+
+// validate checks params. Year, Month and Day are checked only if date is set,
+// since requests for periods and aliases ignore them.
+func (p Params) validate(date bool) error {
+	var errs ValidationErrors
+	add := func(field string, value any, reason string) {
+		errs = append(errs, &ValidationError{Field: field, Value: value, Reason: reason})
+	}
+
+	if date {
+		if p.Year < 1 || p.Year > 9999 {
+			add("Year", p.Year, "must be between 1 and 9999")
+		}
+		monthOK := p.Month == nil || (*p.Month >= time.January && *p.Month <= time.December)
+		if !monthOK {
+			add("Month", int(*p.Month), "must be between 1 and 12")
+		}
+		switch {
+		case p.Day == nil:
+		case p.Month == nil:
+			add("Day", *p.Day, "requires Month")
+		case *p.Day < 1 || *p.Day > 31:
+			add("Day", *p.Day, "must be between 1 and 31")
+		case monthOK:
+			// високосность важна только для февраля, поэтому при неверном годе берём високосный
+			year := p.Year
+			if year < 1 || year > 9999 {
+				year = 2000
+			}
+			if n := time.Date(year, *p.Month+1, 0, 0, 0, 0, 0, time.UTC).Day(); *p.Day > n {
+				add("Day", *p.Day, fmt.Sprintf("%s %d has %d days", *p.Month, year, n))
+			}
+		}
+	}
+	if p.CountryCode != nil && !p.CountryCode.Valid() {
+		add("CountryCode", string(*p.CountryCode), "unknown country code")
+	}
+	if p.TZ != nil {
+		if *p.TZ == "" {
+			add("TZ", `""`, "must not be empty")
+		} else if _, err := time.LoadLocation(*p.TZ); err != nil {
+			add("TZ", *p.TZ, "unknown IANA time zone")
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errs
+}
+
+// validateYear checks the year argument of IsLeap
+func validateYear(year int) error {
+	if year < 1 || year > 9999 {
+		return &ValidationError{Field: "year", Value: year, Reason: "must be between 1 and 9999"}
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package isdayoff
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestParamsValidate(t *testing.T) {
+	month := func(m time.Month) *time.Month { return &m }
+	day := func(d int) *int { return &d }
+	cc := func(code string) *CountryCode { c := CountryCode(code); return &c }
+	tz := func(name string) *string { return &name }
+
+	tests := []struct {
+		name   string
+		params Params
+		fields []string // поля с ошибками, nil — параметры корректны
+	}{
+		{"year only", Params{Year: 2024}, nil},
+		{"full date", Params{Year: 2024, Month: month(time.February), Day: day(29)}, nil},
+		{"all filters", Params{Year: 2024, CountryCode: cc("kz"), TZ: tz("Asia/Almaty")}, nil},
+		{"zero year", Params{}, []string{"Year"}},
+		{"negative year", Params{Year: -1}, []string{"Year"}},
+		{"five digit year", Params{Year: 10000}, []string{"Year"}},
+		{"month 13", Params{Year: 2024, Month: month(13)}, []string{"Month"}},
+		{"month 0", Params{Year: 2024, Month: month(0)}, []string{"Month"}},
+		{"day without month", Params{Year: 2024, Day: day(1)}, []string{"Day"}},
+		{"day 0", Params{Year: 2024, Month: month(time.March), Day: day(0)}, []string{"Day"}},
+		{"31 February", Params{Year: 2024, Month: month(time.February), Day: day(31)}, []string{"Day"}},
+		{"29 February in common year", Params{Year: 2023, Month: month(time.February), Day: day(29)}, []string{"Day"}},
+		{"31 April", Params{Year: 2024, Month: month(time.April), Day: day(31)}, []string{"Day"}},
+		{"unknown country", Params{Year: 2024, CountryCode: cc("xx")}, []string{"CountryCode"}},
+		{"upper case country", Params{Year: 2024, CountryCode: cc("RU")}, []string{"CountryCode"}},
+		{"unknown time zone", Params{Year: 2024, TZ: tz("Mars/Olympus")}, []string{"TZ"}},
+		{"empty time zone", Params{Year: 2024, TZ: tz("")}, []string{"TZ"}},
+		{"several fields", Params{Month: month(13), Day: day(40), CountryCode: cc("xx"), TZ: tz("nowhere")}, []string{"Year", "Month", "Day", "CountryCode", "TZ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.params.Validate()
+			if tt.fields == nil {
+				if err != nil {
+					t.Errorf("Validate() = %v, expected nil", err)
+				}
+				return
+			}
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() = %v, expected ValidationErrors", err)
+			}
+			var fields []string
+			for _, e := range errs {
+				fields = append(fields, e.Field)
+			}
+			if !slices.Equal(fields, tt.fields) {
+				t.Errorf("Validate() reported fields %v, expected %v: %v", fields, tt.fields, err)
+			}
+			var first *ValidationError
+			if !errors.As(err, &first) || first.Field != tt.fields[0] {
+				t.Errorf("errors.As() did not unwrap ValidationError for %s", tt.fields[0])
+			}
+		})
+	}
+}
+
+func TestClientValidatesParams(t *testing.T) {
+	api, client := newFakeAPI(t)
+	unknown := CountryCode("xx")
+	invalid := Params{CountryCode: &unknown}
+
+	calls := map[string]func() error{
+		"IsLeap": func() error {
+			_, err := client.IsLeap(0)
+			return err
+		},
+		"GetBy": func() error {
+			_, err := client.GetBy(Params{})
+			return err
+		},
+		"GetByPeriod": func() error {
+			_, err := client.GetByPeriod("20240101", "20240131", invalid)
+			return err
+		},
+		"Today": func() error {
+			_, err := client.Today(invalid)
+			return err
+		},
+		"Tomorrow": func() error {
+			_, err := client.Tomorrow(invalid)
+			return err
+		},
+		"GetRange": func() error {
+			_, err := client.GetRange(date(2020, time.January, 1), date(2024, time.January, 1), invalid)
+			return err
+		},
+		"Calendar": func() error {
+			_, err := client.Calendar(2024, invalid)
+			return err
+		},
+		"AddWorkingDays": func() error {
+			_, err := client.AddWorkingDays(date(2024, time.January, 1), 3, invalid)
+			return err
+		},
+	}
+	for name, call := range calls {
+		var validationErr *ValidationError
+		if err := call(); !errors.As(err, &validationErr) {
+			t.Errorf("%s() error = %v, expected ValidationError", name, err)
+		}
+	}
+	if got := api.requestCount(); got != 0 {
+		t.Errorf("server got %d requests, expected 0: %v", got, api.requests)
+	}
+
+	// параметры по умолчанию тоже проверяются
+	_, withDefaults := newFakeAPI(t, WithDefaultParams(invalid))
+	if _, err := withDefaults.GetBy(Params{Year: 2024}); err == nil {
+		t.Error("GetBy() accepted invalid default CountryCode")
+	}
+
+	// год, месяц и день не используются запросами за период и игнорируются
+	month := time.Month(13)
+	if _, err := client.GetByPeriod("20240101", "20240102", Params{Month: &month}); err != nil {
+		t.Errorf("GetByPeriod() failed: %v", err)
+	}
+}
@@ -0,0 +1,129 @@
+package isdayoff
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoWorkingDay is returned when no working day is found within a year of the date
+var ErrNoWorkingDay = errors.New("no working day found")
+
+// workdays looks up days, fetching year calendars on demand
+type workdays struct {
+	ctx    context.Context
+	src    Provider
+	params Params
+	years  map[int]*Calendar
+	stale  error
+}
+
+func newWorkdays(ctx context.Context, src Provider, params Params) *workdays {
+	return &workdays{ctx: ctx, src: src, params: params, years: map[int]*Calendar{}}
+}
+
+// calendar returns calendar of the year, fetching it once
+func (w *workdays) calendar(year int) (*Calendar, error) {
+	if cal, ok := w.years[year]; ok {
+		return cal, nil
+	}
+	cal, err := FetchCalendar(w.ctx, w.src, year, w.params)
+	if cal == nil {
+		return nil, err
+	}
+	if err != nil {
+		w.stale = err
+	}
+	w.years[year] = cal
+	return cal, nil
+}
+
+// day returns type of the calendar date of t
+func (w *workdays) day(t time.Time) (DayType, error) {
+	cal, err := w.calendar(t.Year())
+	if err != nil {
+		return "", err
+	}
+	return cal.At(t)
+}
+
+// step returns the nearest working day after t (dir = 1) or before t (dir = -1)
+func (w *workdays) step(t time.Time, dir int) (time.Time, error) {
+	for i := 1; i <= maxPeriodDays; i++ {
+		next := t.AddDate(0, 0, dir*i)
+		day, err := w.day(next)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if day.IsWorking() {
+			return next, nil
+		}
+	}
+	return time.Time{}, ErrNoWorkingDay
+}
+
+// add moves t by n working days
+func (w *workdays) add(t time.Time, n int) (time.Time, error) {
+	dir := 1
+	if n < 0 {
+		dir, n = -1, -n
+	}
+	for range n {
+		var err error
+		if t, err = w.step(t, dir); err != nil {
+			return time.Time{}, err
+		}
+	}
+	return t, w.stale
+}
+
+// NextWorkingDay returns the first working day after t.
+// Time of day and location of t are kept.
+func (c *Client) NextWorkingDay(t time.Time, params Params) (time.Time, error) {
+	return c.AddWorkingDaysContext(context.Background(), t, 1, params)
+}
+
+// NextWorkingDayContext returns the first working day after t using the provided context
+func (c *Client) NextWorkingDayContext(ctx context.Context, t time.Time, params Params) (time.Time, error) {
+	return c.AddWorkingDaysContext(ctx, t, 1, params)
+}
+
+// PrevWorkingDay returns the last working day before t.
+// Time of day and location of t are kept.
+func (c *Client) PrevWorkingDay(t time.Time, params Params) (time.Time, error) {
+	return c.AddWorkingDaysContext(context.Background(), t, -1, params)
+}
+
+// PrevWorkingDayContext returns the last working day before t using the provided context
+func (c *Client) PrevWorkingDayContext(ctx context.Context, t time.Time, params Params) (time.Time, error) {
+	return c.AddWorkingDaysContext(ctx, t, -1, params)
+}
+
+// AddWorkingDays returns the date n working days after t, or before t if n is negative.
+// The day t itself is not counted, so adding 5 to a Friday gives the next Friday
+// in an ordinary week. Zero n returns t unchanged. Year calendars are fetched
+// as needed, so the computation may cross year boundaries.
+// Shortened pre-holiday days and days marked for COVID-19 count as working days.
+func (c *Client) AddWorkingDays(t time.Time, n int, params Params) (time.Time, error) {
+	return c.AddWorkingDaysContext(context.Background(), t, n, params)
+}
+
+// AddWorkingDaysContext moves t by n working days using the provided context
+func (c *Client) AddWorkingDaysContext(ctx context.Context, t time.Time, n int, params Params) (time.Time, error) {
+	return AddWorkingDays(ctx, c, t, n, params)
+}
+
+// NextWorkingDay returns the first working day after t using the provider
+func NextWorkingDay(ctx context.Context, p Provider, t time.Time, params Params) (time.Time, error) {
+	return AddWorkingDays(ctx, p, t, 1, params)
+}
+
+// PrevWorkingDay returns the last working day before t using the provider
+func PrevWorkingDay(ctx context.Context, p Provider, t time.Time, params Params) (time.Time, error) {
+	return AddWorkingDays(ctx, p, t, -1, params)
+}
+
+// AddWorkingDays moves t by n working days using the provider, see Client.AddWorkingDays
+func AddWorkingDays(ctx context.Context, p Provider, t time.Time, n int, params Params) (time.Time, error) {
+	return newWorkdays(ctx, p, params).add(t, n)
+}
@@ -0,0 +1,95 @@
+package isdayoff
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestAddWorkingDays(t *testing.T) {
+	api, client := newFakeAPI(t)
+	for _, d := range []string{"20231229", "20240101", "20240102", "20240103", "20240104", "20240105", "20240108"} {
+		api.holidays[d] = true
+	}
+
+	tests := []struct {
+		name     string
+		from     time.Time
+		n        int
+		expected time.Time
+	}{
+		{"zero", date(2024, time.March, 2), 0, date(2024, time.March, 2)},
+		{"within week", date(2024, time.March, 4), 3, date(2024, time.March, 7)},
+		{"friday plus five", date(2024, time.March, 1), 5, date(2024, time.March, 8)},
+		{"from weekend", date(2024, time.March, 2), 1, date(2024, time.March, 4)},
+		{"over new year holidays", date(2023, time.December, 27), 2, date(2024, time.January, 9)},
+		{"backwards over new year holidays", date(2024, time.January, 9), -2, date(2023, time.December, 27)},
+		{"backwards over weekend", date(2024, time.March, 4), -1, date(2024, time.March, 1)},
+		{"across several years", date(2022, time.January, 3), 522, date(2024, time.January, 12)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := client.AddWorkingDays(tt.from, tt.n, Params{})
+			if err != nil {
+				t.Fatalf("AddWorkingDays(%v, %d) failed: %v", tt.from, tt.n, err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("AddWorkingDays(%v, %d) = %v, expected %v", tt.from.Format(time.DateOnly), tt.n, got.Format(time.DateOnly), tt.expected.Format(time.DateOnly))
+			}
+		})
+	}
+}
+
+func TestNextPrevWorkingDay(t *testing.T) {
+	api, client := newFakeAPI(t)
+	api.holidays["20240101"] = true
+
+	msk := time.FixedZone("MSK", 3*60*60)
+	from := time.Date(2023, time.December, 29, 18, 45, 0, 0, msk)
+
+	next, err := client.NextWorkingDay(from, Params{})
+	if err != nil {
+		t.Fatalf("NextWorkingDay() failed: %v", err)
+	}
+	if expected := time.Date(2024, time.January, 2, 18, 45, 0, 0, msk); !next.Equal(expected) || next.Location() != msk {
+		t.Errorf("NextWorkingDay() = %v, expected %v keeping time and location", next, expected)
+	}
+
+	prev, err := client.PrevWorkingDay(next, Params{})
+	if err != nil {
+		t.Fatalf("PrevWorkingDay() failed: %v", err)
+	}
+	if !prev.Equal(from) {
+		t.Errorf("PrevWorkingDay() = %v, expected %v", prev, from)
+	}
+
+	// при шестидневной неделе суббота рабочая
+	sd := true
+	next, err = client.NextWorkingDay(date(2024, time.March, 1), Params{SixDayWeek: &sd})
+	if err != nil {
+		t.Fatalf("NextWorkingDay() with six-day week failed: %v", err)
+	}
+	if !next.Equal(date(2024, time.March, 2)) {
+		t.Errorf("NextWorkingDay() with six-day week = %v, expected Saturday", next.Format(time.DateOnly))
+	}
+}
+
+func TestAddWorkingDaysErrors(t *testing.T) {
+	api, client := newFakeAPI(t)
+	for d := date(2024, time.January, 1); d.Year() < 2026; d = d.AddDate(0, 0, 1) {
+		api.holidays[d.Format("20060102")] = true
+	}
+	if _, err := client.NextWorkingDay(date(2024, time.January, 1), Params{}); !errors.Is(err, ErrNoWorkingDay) {
+		t.Errorf("NextWorkingDay() error = %v, expected ErrNoWorkingDay", err)
+	}
+
+	broken := newTestClient(t, http404())
+	var apiErr *APIError
+	if _, err := broken.AddWorkingDays(date(2024, time.January, 1), 3, Params{}); !errors.As(err, &apiErr) {
+		t.Errorf("AddWorkingDays() error = %v, expected APIError", err)
+	}
+}